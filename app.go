@@ -28,6 +28,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/dig"
@@ -248,7 +249,7 @@ func (l loggerOption) apply(m *module) {
 			"not to fx.Module")
 	} else {
 		np := writerFromPrinter(l.p)
-		m.log = fxlog.DefaultLogger(np) // assuming np is thread-safe.
+		m.log = m.app.syncLogger(fxlog.DefaultLogger(np))
 	}
 }
 
@@ -314,9 +315,64 @@ type App struct {
 	// Whether to recover from panics in Dig container
 	recoverFromPanics bool
 
+	// Functions that may replace lifecycle hooks before they run.
+	hookInterceptors []lifecycle.Interceptor
+
 	// Used to signal shutdowns.
 	receivers signalReceivers
 
+	// If set, receives the signals sent by the Shutdowner
+	// instead of broadcasting them.
+	recordShutdown func(ShutdownSignal)
+
+	// If set, the application was built by fxtest.New,
+	// which receives the values of fxtest options with this.
+	onTestOption func(value, scope interface{})
+
+	// Thresholds set with SlowThreshold. Zero if disabled.
+	slowHookThreshold      time.Duration
+	slowConstructThreshold time.Duration
+
+	// Context passed to NewContext, limited by ConstructTimeout.
+	// nil if neither was used.
+	constructCtx     context.Context
+	constructTimeout time.Duration
+
+	// Functions running during construction, tracked if constructCtx is set.
+	running runningFuncs
+
+	// Guards the App against construction that's been abandoned
+	// because constructCtx is done.
+	construct constructGuard
+
+	// Serializes the events logged to the loggers of the App.
+	logMu sync.Mutex
+
+	// AppContext provided to constructors,
+	// cancelled when the application stops.
+	appCtx       context.Context
+	cancelAppCtx context.CancelFunc
+
+	// Constructors and supplied values added to the container,
+	// in the order they were provided.
+	constructors []*constructorInfo
+
+	// Constructors, decorators, and stubs that were run by Dig,
+	// in the order they finished running.
+	runs []*fxevent.Run
+
+	// Sum of the runtimes of runs.
+	runRuntime time.Duration
+
+	// Module that appended each lifecycle hook, by hook index.
+	hookModules []*module
+
+	// Error passed to ShutdownError, if any. It's pending until
+	// the application has stopped, and then it's reported by Err.
+	shutdownMu         sync.Mutex
+	pendingShutdownErr error
+	shutdownErr        error
+
 	osExit func(code int) // os.Exit override; used for testing only
 }
 
@@ -334,6 +390,9 @@ type provide struct {
 
 	// Set if the type should be provided at private scope.
 	Private bool
+
+	// Set for the values that Fx provides to every application.
+	IsDefault bool
 }
 
 // invoke is a single invocation request to Fx.
@@ -420,16 +479,23 @@ func ValidateApp(opts ...Option) error {
 // registered via [Invoke] options. See the documentation of the App struct for
 // details on the application's initialization, startup, and shutdown logic.
 func New(opts ...Option) *App {
-	logger := fxlog.DefaultLogger(os.Stderr)
+	return newApp(nil, opts)
+}
 
+// newApp builds an App for New or NewContext.
+// ctx is nil if the App was built with New.
+func newApp(ctx context.Context, opts []Option) *App {
 	app := &App{
 		clock:        fxclock.System,
 		startTimeout: DefaultTimeout,
 		stopTimeout:  DefaultTimeout,
 		receivers:    newSignalReceivers(),
 	}
+	logger := app.syncLogger(fxlog.DefaultLogger(os.Stderr))
+	location := fxreflect.CallerStack(2, 3)[0]
 	app.root = &module{
-		app: app,
+		app:      app,
+		location: location,
 		// We start with a logger that writes to stderr. One of the
 		// following three things can change this:
 		//
@@ -443,13 +509,25 @@ func New(opts ...Option) *App {
 		// back to what was provided to fx.Logger if fx.WithLogger
 		// fails.
 		log:   logger,
-		trace: []string{fxreflect.CallerStack(1, 2)[0].String()},
+		trace: []string{location.String()},
 	}
 
 	for _, opt := range opts {
 		opt.apply(app.root)
 	}
 
+	if app.constructTimeout > 0 {
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = app.clock.WithTimeout(ctx, app.constructTimeout)
+		defer cancel()
+	}
+	app.constructCtx = ctx
+
+	app.newAppContext(ctx)
+
 	// There are a few levels of wrapping on the lifecycle here. To quickly
 	// cover them:
 	//
@@ -460,7 +538,13 @@ func New(opts ...Option) *App {
 	// - appLogger ensures that the lifecycle always logs events to the
 	//   "current" logger associated with the fx.App.
 	app.lifecycle = &lifecycleWrapper{
-		lifecycle.New(appLogger{app}, app.clock),
+		Lifecycle: lifecycle.New(appLogger{app}, app.clock),
+		construct: &app.construct,
+	}
+	app.lifecycle.SetSlowThreshold(app.slowHookThreshold)
+	app.lifecycle.SetModuleOf(app.hookModule)
+	if len(app.hookInterceptors) > 0 {
+		app.lifecycle.SetInterceptor(app.interceptHooks)
 	}
 
 	containerOptions := []dig.Option{
@@ -478,13 +562,27 @@ func New(opts ...Option) *App {
 	// Provide Fx types first to increase the chance a custom logger
 	// can be successfully built in the face of unrelated DI failure.
 	// E.g., for a custom logger that relies on the Lifecycle type.
-	frames := fxreflect.CallerStack(0, 0) // include New in the stack for default Provides
+	frames := fxreflect.CallerStack(1, 0) // include New in the stack for default Provides
+	app.root.provide(provide{
+		Target:    func() Lifecycle { return app.lifecycle },
+		Stack:     frames,
+		IsDefault: true,
+	})
+	app.root.provide(provide{Target: app.shutdowner, Stack: frames, IsDefault: true})
+	app.root.provide(provide{Target: app.dotGraph, Stack: frames, IsDefault: true})
+	app.root.provide(provide{Target: app.debugHandler, Stack: frames, IsDefault: true})
 	app.root.provide(provide{
-		Target: func() Lifecycle { return app.lifecycle },
-		Stack:  frames,
+		Target:    func() AppContext { return app.appCtx },
+		Stack:     frames,
+		IsDefault: true,
 	})
-	app.root.provide(provide{Target: app.shutdowner, Stack: frames})
-	app.root.provide(provide{Target: app.dotGraph, Stack: frames})
+	if ctx != nil {
+		app.root.provide(provide{
+			Target:    func() ConstructContext { return ctx },
+			Stack:     frames,
+			IsDefault: true,
+		})
+	}
 	app.root.provideAll()
 
 	// Run decorators before executing any Invokes
@@ -504,7 +602,7 @@ func New(opts ...Option) *App {
 		return app
 	}
 
-	if err := app.root.invokeAll(); err != nil {
+	if err := app.invokeAll(); err != nil {
 		app.err = err
 
 		if dig.CanVisualizeError(err) {
@@ -597,31 +695,89 @@ func (app *App) Run() {
 	// Historically, we do not os.Exit(0) even though most applications
 	// cede control to Fx with they call app.Run. To avoid a breaking
 	// change, never os.Exit for success.
-	if code := app.run(app.Wait); code != 0 {
+	if code, _ := app.run(context.Background(), app.waitSourced); code != 0 {
 		app.exit(code)
 	}
 }
 
-func (app *App) run(done func() <-chan ShutdownSignal) (exitCode int) {
-	startCtx, cancel := app.clock.WithTimeout(context.Background(), app.StartTimeout())
+// RunContext is like [App.Run], but it also stops the application when ctx
+// is done, and it never exits the process.
+// This makes it suitable for applications embedded in a larger program,
+// such as a subcommand of a command line tool.
+//
+// RunContext returns the error that caused startup or shutdown to fail,
+// or the error given to [ShutdownError] or [ShutdownReason].
+// If the application would have exited with a non-zero exit code,
+// the error is an [*ExitError] that reports the code.
+//
+//	if err := app.RunContext(ctx); err != nil {
+//		var exitErr *fx.ExitError
+//		if errors.As(err, &exitErr) {
+//			os.Exit(exitErr.Code)
+//		}
+//		...
+//	}
+//
+// Values in ctx are available to OnStart and OnStop hooks, but the
+// cancellation of ctx does not cut OnStop hooks short.
+func (app *App) RunContext(ctx context.Context) error {
+	code, err := app.run(ctx, app.waitSourced)
+	if code != 0 {
+		return &ExitError{Code: code, Err: err}
+	}
+	return err
+}
+
+// ExitError is returned by [App.RunContext] if the application would have
+// exited with a non-zero exit code.
+type ExitError struct {
+	// Code is the exit code: 1 if the application failed to start or stop,
+	// or the code given to [ExitCode].
+	Code int
+
+	// Err is the error that caused the exit code, if any.
+	Err error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+	return fmt.Sprintf("exit code %d: %v", e.Code, e.Err)
+}
+
+// Unwrap returns the error that caused the exit code.
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// run starts the application,
+// and stops it once done delivers a signal or ctx is done.
+func (app *App) run(ctx context.Context, done func() <-chan sourcedSignal) (exitCode int, err error) {
+	startCtx, cancel := app.clock.WithTimeout(ctx, app.StartTimeout())
 	defer cancel()
 
 	if err := app.Start(startCtx); err != nil {
-		return 1
+		return 1, err
 	}
 
-	sig := <-done()
-	app.log().LogEvent(&fxevent.Stopping{Signal: sig.Signal})
-	exitCode = sig.ExitCode
+	var sig ShutdownSignal
+	select {
+	case s := <-done():
+		sig = s.signal
+		app.log().LogEvent(sig.stoppingEvent(s.source))
+	case <-ctx.Done():
+		app.log().LogEvent(sig.stoppingEvent(shutdownSource{origin: "context"}))
+	}
 
-	stopCtx, cancel := app.clock.WithTimeout(context.Background(), app.StopTimeout())
+	stopCtx, cancel := app.clock.WithTimeout(context.WithoutCancel(ctx), app.StopTimeout())
 	defer cancel()
 
 	if err := app.Stop(stopCtx); err != nil {
-		return 1
+		return 1, multierr.Append(sig.Reason, err)
 	}
 
-	return exitCode
+	return sig.ExitCode, sig.Reason
 }
 
 // Err returns any error encountered during New's initialization. See the
@@ -631,8 +787,37 @@ func (app *App) run(done func() <-chan ShutdownSignal) (exitCode int) {
 //
 // Most users won't need to use this method, since both Run and Start
 // short-circuit if initialization failed.
+//
+// If initialization succeeded but the application was shut down with
+// [ShutdownError], Err returns the error passed to it once Stop has
+// completed.
 func (app *App) Err() error {
-	return app.err
+	if app.err != nil {
+		return app.err
+	}
+
+	app.shutdownMu.Lock()
+	defer app.shutdownMu.Unlock()
+	return app.shutdownErr
+}
+
+// setShutdownErr records the error passed to ShutdownError.
+// Err reports it after the application stops.
+func (app *App) setShutdownErr(err error) {
+	app.shutdownMu.Lock()
+	defer app.shutdownMu.Unlock()
+	app.pendingShutdownErr = err
+}
+
+// reportShutdownErr makes Err report the error passed to ShutdownError,
+// if any. It's called once the application has stopped.
+func (app *App) reportShutdownErr() {
+	app.shutdownMu.Lock()
+	defer app.shutdownMu.Unlock()
+	if app.pendingShutdownErr != nil {
+		app.shutdownErr = app.pendingShutdownErr
+		app.pendingShutdownErr = nil
+	}
 }
 
 var (
@@ -660,6 +845,9 @@ var (
 func (app *App) Start(ctx context.Context) (err error) {
 	defer func() {
 		app.log().LogEvent(&fxevent.Started{Err: err})
+		if err == nil {
+			app.log().LogEvent(app.startupSummary())
+		}
 	}()
 
 	if app.err != nil {
@@ -683,6 +871,7 @@ func (app *App) withRollback(
 ) error {
 	if err := f(ctx); err != nil {
 		app.log().LogEvent(&fxevent.RollingBack{StartErr: err})
+		app.cancelAppCtx()
 
 		stopErr := app.lifecycle.Stop(ctx)
 		app.log().LogEvent(&fxevent.RolledBack{Err: stopErr})
@@ -698,6 +887,10 @@ func (app *App) withRollback(
 }
 
 func (app *App) start(ctx context.Context) error {
+	// Hooks appended outside of constructors and invokes
+	// are attributed to the root module.
+	app.attributeHooks(app.root)
+
 	return app.withRollback(ctx, func(ctx context.Context) error {
 		if err := app.lifecycle.Start(ctx); err != nil {
 			return err
@@ -715,9 +908,12 @@ func (app *App) start(ctx context.Context) error {
 // fail.
 func (app *App) Stop(ctx context.Context) (err error) {
 	defer func() {
+		app.reportShutdownErr()
 		app.log().LogEvent(&fxevent.Stopped{Err: err})
 	}()
 
+	app.cancelAppCtx()
+
 	cb := func(ctx context.Context) error {
 		defer app.receivers.Stop(ctx)
 		return app.lifecycle.Stop(ctx)
@@ -754,6 +950,12 @@ func (app *App) Wait() <-chan ShutdownSignal {
 	return app.receivers.Wait()
 }
 
+// waitSourced is like Wait, but also reports where each signal came from.
+func (app *App) waitSourced() <-chan sourcedSignal {
+	app.receivers.Start() // No-op if running
+	return app.receivers.WaitSourced()
+}
+
 // StartTimeout returns the configured startup timeout.
 // This defaults to [DefaultTimeout], and can be changed with the
 // [StartTimeout] option.
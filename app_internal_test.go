@@ -43,29 +43,105 @@ func TestAppRun(t *testing.T) {
 	app := New(
 		WithLogger(func() fxevent.Logger { return spy }),
 	)
-	done := make(chan ShutdownSignal)
+	done := make(chan sourcedSignal)
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		app.run(func() <-chan ShutdownSignal { return done })
+		app.run(context.Background(), func() <-chan sourcedSignal { return done })
 	}()
 
-	done <- ShutdownSignal{Signal: _sigINT}
+	done <- sourcedSignal{signal: ShutdownSignal{Signal: _sigINT}}
 	wg.Wait()
 
 	assert.Equal(t, []string{
+		"Provided",
+		"Provided",
 		"Provided",
 		"Provided",
 		"Provided",
 		"LoggerInitialized",
 		"Started",
+		"StartupSummary",
 		"Stopping",
 		"Stopped",
 	}, spy.EventTypes())
 }
 
+func TestAppRunStopping(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signal", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		app := New(WithLogger(func() fxevent.Logger { return spy }))
+		done := make(chan sourcedSignal, 1)
+		done <- sourcedSignal{signal: ShutdownSignal{Signal: _sigINT}}
+		app.run(context.Background(), func() <-chan sourcedSignal { return done })
+
+		events := spy.Events().SelectByTypeName("Stopping")
+		require.Len(t, events, 1)
+		assert.Equal(t, &fxevent.Stopping{
+			Signal: _sigINT,
+			Origin: "signal",
+		}, events[0])
+	})
+
+	t.Run("shutdowner", func(t *testing.T) {
+		t.Parallel()
+
+		reason := errors.New("great sadness")
+		spy := new(fxlog.Spy)
+		app := New(
+			WithLogger(func() fxevent.Logger { return spy }),
+			Invoke(func(s Shutdowner) error {
+				return s.Shutdown(ExitCode(3), ShutdownReason(reason))
+			}),
+		)
+		require.NoError(t, app.Err())
+		code, err := app.run(context.Background(), app.waitSourced)
+		assert.Equal(t, 3, code)
+		assert.Same(t, reason, err)
+
+		events := spy.Events().SelectByTypeName("Stopping")
+		require.Len(t, events, 1)
+		e := events[0].(*fxevent.Stopping)
+		assert.Equal(t, _sigTERM, e.Signal)
+		assert.Equal(t, "shutdowner", e.Origin)
+		assert.Contains(t, e.Caller, "TestAppRunStopping")
+		assert.Contains(t, e.Caller, "app_internal_test.go")
+		assert.Equal(t, 3, e.ExitCode)
+		assert.Same(t, reason, e.Reason)
+		assert.False(t, e.Failed)
+		assert.Equal(t, fxevent.SeverityInfo, fxevent.SeverityOf(e))
+	})
+
+	t.Run("shutdown error", func(t *testing.T) {
+		t.Parallel()
+
+		giveErr := errors.New("great sadness")
+		spy := new(fxlog.Spy)
+		app := New(
+			WithLogger(func() fxevent.Logger { return spy }),
+			Invoke(func(s Shutdowner) error {
+				return s.Shutdown(ShutdownError(giveErr))
+			}),
+		)
+		code, err := app.run(context.Background(), app.waitSourced)
+		assert.Equal(t, 1, code)
+		assert.Same(t, giveErr, err)
+
+		events := spy.Events().SelectByTypeName("Stopping")
+		require.Len(t, events, 1)
+		e := events[0].(*fxevent.Stopping)
+		assert.Same(t, giveErr, e.Reason)
+		assert.True(t, e.Failed)
+		assert.Equal(t, fxevent.SeverityError, fxevent.SeverityOf(e))
+	})
+}
+
 // TestValidateString verifies private option. Public options are tested in app_test.go.
 func TestValidateString(t *testing.T) {
 	t.Parallel()
@@ -98,16 +174,6 @@ func WithClock(clock fxclock.Clock) Option {
 	return withClockOption{clock}
 }
 
-type withClockOption struct{ clock fxclock.Clock }
-
-func (o withClockOption) apply(m *module) {
-	m.app.clock = o.clock
-}
-
-func (o withClockOption) String() string {
-	return fmt.Sprintf("WithClock(%v)", o.clock)
-}
-
 func TestAnnotationError(t *testing.T) {
 	wantErr := errors.New("want error")
 	err := &annotationError{
@@ -138,3 +204,27 @@ func TestStartDoesNotRegisterSignals(t *testing.T) {
 	_ = app.Wait() // User signals intent have fx listen for signals. This should call notify
 	assert.True(t, calledNotify, "notify should be called after Wait")
 }
+
+func TestAbandonedConstruction(t *testing.T) {
+	t.Parallel()
+
+	// NewContext abandons construction from another goroutine once its
+	// context is done. Abandon it from an invoke instead to find out
+	// deterministically what runs afterwards.
+	var ran []string
+	app := New(
+		NopLogger,
+		Module("first",
+			Invoke(func(lc Lifecycle) {
+				ran = append(ran, "first")
+				lc.(*lifecycleWrapper).construct.abandon()
+			}),
+			Invoke(func() { ran = append(ran, "second") }),
+		),
+		Module("second", Invoke(func() { ran = append(ran, "other module") })),
+		Invoke(func() { ran = append(ran, "root") }),
+	)
+	assert.ErrorIs(t, app.Err(), errConstructAbandoned)
+	assert.Equal(t, []string{"first"}, ran,
+		"no invokes should run once construction is abandoned")
+}
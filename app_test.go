@@ -110,7 +110,7 @@ func TestNewApp(t *testing.T) {
 			WithLogger(func() fxevent.Logger { return spy }))
 		defer app.RequireStart().RequireStop()
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "Provided", "LoggerInitialized", "Started"},
+			[]string{"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "LoggerInitialized", "Started", "StartupSummary"},
 			spy.EventTypes())
 
 		// Fx types get provided first to increase chance of
@@ -118,8 +118,44 @@ func TestNewApp(t *testing.T) {
 		assert.Contains(t, spy.Events()[0].(*fxevent.Provided).OutputTypeNames, "fx.Lifecycle")
 		assert.Contains(t, spy.Events()[1].(*fxevent.Provided).OutputTypeNames, "fx.Shutdowner")
 		assert.Contains(t, spy.Events()[2].(*fxevent.Provided).OutputTypeNames, "fx.DotGraph")
-		// Our type should be index 3.
-		assert.Contains(t, spy.Events()[3].(*fxevent.Provided).OutputTypeNames, "struct {}")
+		assert.Contains(t, spy.Events()[3].(*fxevent.Provided).OutputTypeNames, "fx.DebugHandler")
+		assert.Contains(t, spy.Events()[4].(*fxevent.Provided).OutputTypeNames, "fx.AppContext")
+		// Our type should be index 5.
+		assert.Contains(t, spy.Events()[5].(*fxevent.Provided).OutputTypeNames, "struct {}")
+	})
+
+	t.Run("ProvidedAndDecoratedIncludeInputs", func(t *testing.T) {
+		t.Parallel()
+
+		type A struct{}
+		type B struct{}
+		type params struct {
+			In
+
+			A  A   `name:"a" optional:"true"`
+			As []A `group:"as"`
+		}
+
+		spy := new(fxlog.Spy)
+		app := fxtest.New(t,
+			WithLogger(func() fxevent.Logger { return spy }),
+			Provide(func(params) B { return B{} }),
+			Decorate(func(b B, _ Lifecycle) B { return b }),
+			Invoke(func(B) {}),
+		)
+		defer app.RequireStart().RequireStop()
+
+		provided := spy.Events().SelectByTypeName("Provided")
+		require.Len(t, provided, 6)
+		assert.Equal(t, []string{
+			`fx_test.A[optional, name = "a"]`,
+			`[]fx_test.A[group = "as"]`,
+		}, provided[5].(*fxevent.Provided).InputTypeNames)
+
+		decorated := spy.Events().SelectByTypeName("Decorated")
+		require.Len(t, decorated, 1)
+		assert.Equal(t, []string{"fx_test.B", "fx.Lifecycle"},
+			decorated[0].(*fxevent.Decorated).InputTypeNames)
 	})
 
 	t.Run("CircularGraphReturnsError", func(t *testing.T) {
@@ -365,7 +401,7 @@ func TestNewApp(t *testing.T) {
 		defer app.RequireStart().RequireStop()
 
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "Provided", "Decorated", "LoggerInitialized", "Invoking", "Run", "Run", "Invoked", "Started"},
+			[]string{"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Decorated", "LoggerInitialized", "Invoking", "Run", "Run", "Invoked", "Started", "StartupSummary"},
 			spy.EventTypes())
 	})
 
@@ -386,7 +422,10 @@ func TestNewApp(t *testing.T) {
 		defer app.RequireStart().RequireStop()
 
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "Provided", "Decorated", "Decorated", "LoggerInitialized", "Started"},
+			[]string{
+				"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Decorated", "Decorated",
+				"LoggerInitialized", "ModuleBuilding", "ModuleBuilt", "Started", "StartupSummary",
+			},
 			spy.EventTypes())
 	})
 }
@@ -674,7 +713,7 @@ func TestWithLogger(t *testing.T) {
 		)
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided", "Supplied", "Run", "LoggerInitialized",
+			"Provided", "Provided", "Provided", "Provided", "Provided", "Supplied", "Run", "LoggerInitialized",
 		}, spy.EventTypes())
 
 		spy.Reset()
@@ -682,7 +721,7 @@ func TestWithLogger(t *testing.T) {
 
 		require.NoError(t, app.Err())
 
-		assert.Equal(t, []string{"Started", "Stopped"}, spy.EventTypes())
+		assert.Equal(t, []string{"Started", "StartupSummary", "Stopped"}, spy.EventTypes())
 	})
 
 	t.Run("error in Provide shows logs", func(t *testing.T) {
@@ -704,7 +743,7 @@ func TestWithLogger(t *testing.T) {
 			"must provide constructor function, got  (type *bytes.Buffer)",
 		)
 
-		assert.Equal(t, []string{"Provided", "Provided", "Provided", "Supplied", "Provided", "Run", "LoggerInitialized"}, spy.EventTypes())
+		assert.Equal(t, []string{"Provided", "Provided", "Provided", "Provided", "Provided", "Supplied", "Provided", "Run", "LoggerInitialized"}, spy.EventTypes())
 	})
 
 	t.Run("logger failed to build", func(t *testing.T) {
@@ -1265,9 +1304,9 @@ func TestOptions(t *testing.T) {
 			Provide(&bytes.Buffer{}), // error, not a constructor
 			WithLogger(func() fxevent.Logger { return spy }),
 		)
-		require.Equal(t, []string{"Provided", "Provided", "Provided", "Provided", "LoggerInitialized"}, spy.EventTypes())
-		// First 3 provides are Fx types (Lifecycle, Shutdowner, DotGraph).
-		assert.Contains(t, spy.Events()[3].(*fxevent.Provided).Err.Error(), "must provide constructor function")
+		require.Equal(t, []string{"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "LoggerInitialized"}, spy.EventTypes())
+		// First 5 provides are Fx types (Lifecycle, Shutdowner, DotGraph, DebugHandler, AppContext).
+		assert.Contains(t, spy.Events()[5].(*fxevent.Provided).Err.Error(), "must provide constructor function")
 	})
 }
 
@@ -1445,6 +1484,136 @@ func TestAppRunTimeout(t *testing.T) {
 	}
 }
 
+func TestAppRunContext(t *testing.T) {
+	t.Parallel()
+
+	type ctxKey struct{}
+
+	t.Run("context done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "foo"))
+		defer cancel()
+
+		var stopErr, stopValue any
+		spy := new(fxlog.Spy)
+		app := New(
+			// Cancel once the application has started.
+			WithLogger(func() fxevent.Logger {
+				return cancelOnStarted{spy, cancel}
+			}),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStop: func(ctx context.Context) error {
+						stopErr = ctx.Err()
+						stopValue = ctx.Value(ctxKey{})
+						return nil
+					},
+				})
+			}),
+		)
+
+		require.NoError(t, app.RunContext(ctx))
+		assert.Nil(t, stopErr, "OnStop context must not be cancelled")
+		assert.Equal(t, "foo", stopValue, "OnStop context must have values of ctx")
+
+		events := spy.Events().SelectByTypeName("Stopping")
+		require.Len(t, events, 1)
+		assert.Equal(t, "context", events[0].(*fxevent.Stopping).Origin)
+	})
+
+	t.Run("shutdown with exit code", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(
+			NopLogger,
+			Invoke(func(sd Shutdowner) error {
+				return sd.Shutdown(ExitCode(2))
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 2, exitErr.Code)
+		assert.NoError(t, exitErr.Err)
+		assert.EqualError(t, err, "exit code 2")
+	})
+
+	t.Run("shutdown error", func(t *testing.T) {
+		t.Parallel()
+
+		giveErr := errors.New("great sadness")
+		app := New(
+			NopLogger,
+			Invoke(func(sd Shutdowner) error {
+				return sd.Shutdown(ShutdownError(giveErr))
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.Code)
+		assert.ErrorIs(t, err, giveErr)
+		assert.EqualError(t, err, "exit code 1: great sadness")
+	})
+
+	t.Run("start error", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(
+			NopLogger,
+			Invoke(func(lc Lifecycle) {
+				lc.Append(StartHook(func() error {
+					return errors.New("great sadness")
+				}))
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.Code)
+		assert.ErrorContains(t, err, "great sadness")
+	})
+
+	t.Run("stop error", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(
+			NopLogger,
+			Invoke(func(lc Lifecycle, sd Shutdowner) {
+				lc.Append(StopHook(func() error {
+					return errors.New("great sadness")
+				}))
+				sd.Shutdown()
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		var exitErr *ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.Code)
+		assert.ErrorContains(t, err, "great sadness")
+	})
+}
+
+// cancelOnStarted is an event logger that cancels a context
+// after the application has started.
+type cancelOnStarted struct {
+	fxevent.Logger
+
+	cancel context.CancelFunc
+}
+
+func (l cancelOnStarted) LogEvent(e fxevent.Event) {
+	l.Logger.LogEvent(e)
+	if _, ok := e.(*fxevent.Started); ok {
+		l.cancel()
+	}
+}
+
 func TestAppStart(t *testing.T) {
 	t.Parallel()
 
@@ -1693,7 +1862,7 @@ func TestAppStart(t *testing.T) {
 		assert.Contains(t, err.Error(), "OnStart fail")
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized",
 			"Invoking",
 			"Run",
@@ -1731,7 +1900,7 @@ func TestAppStart(t *testing.T) {
 		assert.Equal(t, []error{errStart2, errStop1}, multierr.Errors(err))
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized",
 			"Invoking",
 			"Run",
@@ -1764,7 +1933,7 @@ func TestAppStart(t *testing.T) {
 		//         /.../go/1.13.3/libexec/src/testing/testing.go:909
 		// Failed: can't invoke non-function {} (type struct {})
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "LoggerInitialized", "Invoking", "Invoked"},
+			[]string{"Provided", "Provided", "Provided", "Provided", "Provided", "LoggerInitialized", "Invoking", "Invoked"},
 			spy.EventTypes())
 		failedEvent := spy.Events()[len(spy.EventTypes())-1].(*fxevent.Invoked)
 		assert.Contains(t, failedEvent.Err.Error(), "can't invoke non-function")
@@ -2381,11 +2550,14 @@ func TestReplaceLogger(t *testing.T) {
 	app := fxtest.New(t, WithLogger(func() fxevent.Logger { return spy }))
 	app.RequireStart().RequireStop()
 	assert.Equal(t, []string{
+		"Provided",
+		"Provided",
 		"Provided",
 		"Provided",
 		"Provided",
 		"LoggerInitialized",
 		"Started",
+		"StartupSummary",
 		"Stopped",
 	}, spy.EventTypes())
 }
@@ -2468,6 +2640,8 @@ func TestCustomLoggerWithLifecycle(t *testing.T) {
 	require.NoError(t, app.Stop(context.Background()))
 
 	assert.Equal(t, []string{
+		"Provided",
+		"Provided",
 		"Provided",
 		"Provided",
 		"Provided",
@@ -2475,6 +2649,7 @@ func TestCustomLoggerWithLifecycle(t *testing.T) {
 		"LoggerInitialized",
 		"OnStartExecuting", "OnStartExecuted",
 		"Started",
+		"StartupSummary",
 		"OnStopExecuting", "OnStopExecuted",
 		"Stopped",
 	}, spy.EventTypes())
@@ -2663,6 +2838,16 @@ func TestOptionString(t *testing.T) {
 			give: StartTimeout(time.Second),
 			want: "fx.StartTimeout(1s)",
 		},
+		{
+			desc: "SlowThreshold",
+			give: SlowThreshold(time.Second, 100*time.Millisecond),
+			want: "fx.SlowThreshold(1s, 100ms)",
+		},
+		{
+			desc: "ConstructTimeout",
+			give: ConstructTimeout(time.Second),
+			want: "fx.ConstructTimeout(1s)",
+		},
 		{
 			desc: "StopTimeout",
 			give: StopTimeout(5 * time.Second),
@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AppContext is a [context.Context] that lives as long as the application.
+// Fx provides it to all constructors and invoked functions.
+// It's cancelled as soon as the application starts to stop,
+// before any OnStop hooks run, or if the application fails to start.
+//
+// Use it to stop background work without an OnStop hook of its own.
+//
+//	func NewPoller(ctx fx.AppContext, lc fx.Lifecycle) *Poller {
+//		p := &Poller{}
+//		lc.Append(fx.StartHook(func() {
+//			go p.poll(ctx)
+//		}))
+//		return p
+//	}
+//
+// Use [AppNameFromContext] and [ModuleNameFromContext] to look up
+// the values it carries.
+// If the application was built with [NewContext],
+// it also carries the values of that context.
+type AppContext context.Context
+
+type (
+	appNameKey    struct{}
+	moduleNameKey struct{}
+)
+
+// AppNameFromContext returns the name of the application that provided ctx,
+// or an empty string if ctx is not derived from an [AppContext].
+// This is the name of the running program.
+func AppNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(appNameKey{}).(string)
+	return name
+}
+
+// ModuleNameFromContext returns the name of the [Module] that ctx was
+// provided to, or an empty string if it was provided to the top-level
+// application or ctx is not derived from an [AppContext].
+func ModuleNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(moduleNameKey{}).(string)
+	return name
+}
+
+// newAppContext builds the AppContext for app,
+// deriving its values from parent if it's not nil.
+func (app *App) newAppContext(parent context.Context) {
+	if parent == nil {
+		parent = context.Background()
+	} else {
+		parent = context.WithoutCancel(parent)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	app.appCtx, app.cancelAppCtx = context.WithCancel(
+		context.WithValue(parent, appNameKey{}, name))
+}
+
+// provideAppContext provides an AppContext for this module's scope,
+// carrying the module's name.
+// The top-level AppContext is provided along with the other Fx types.
+func (m *module) provideAppContext() {
+	ctx := AppContext(context.WithValue(m.app.appCtx, moduleNameKey{}, m.name))
+	if err := m.scope.Provide(func() AppContext { return ctx }); err != nil {
+		m.app.err = err
+	}
+}
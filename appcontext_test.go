@@ -0,0 +1,136 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestAppContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancelled on stop", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			appCtx  AppContext
+			stopErr error
+		)
+		app := fxtest.New(t,
+			Invoke(func(ctx AppContext, lc Lifecycle) {
+				appCtx = ctx
+				lc.Append(StopHook(func() {
+					stopErr = ctx.Err()
+				}))
+			}),
+		)
+		require.NotNil(t, appCtx)
+		assert.NotEmpty(t, AppNameFromContext(appCtx))
+		assert.Empty(t, ModuleNameFromContext(appCtx))
+
+		app.RequireStart()
+		assert.NoError(t, appCtx.Err(), "must not be cancelled while running")
+
+		app.RequireStop()
+		assert.ErrorIs(t, appCtx.Err(), context.Canceled)
+		assert.ErrorIs(t, stopErr, context.Canceled,
+			"must be cancelled before OnStop hooks run")
+	})
+
+	t.Run("cancelled on failed start", func(t *testing.T) {
+		t.Parallel()
+
+		var appCtx AppContext
+		app := New(
+			NopLogger,
+			Invoke(func(ctx AppContext, lc Lifecycle) {
+				appCtx = ctx
+				lc.Append(StartHook(func() error {
+					return errors.New("great sadness")
+				}))
+			}),
+		)
+		require.Error(t, app.Start(context.Background()))
+		assert.ErrorIs(t, appCtx.Err(), context.Canceled)
+	})
+
+	t.Run("module names", func(t *testing.T) {
+		t.Parallel()
+
+		type A struct{ ctx context.Context }
+
+		var rootCtx, childCtx, grandchildCtx AppContext
+		var a *A
+		fxtest.New(t,
+			Module("child",
+				Provide(func(ctx AppContext) *A { return &A{ctx} }),
+				Invoke(func(ctx AppContext) { childCtx = ctx }),
+				Module("grandchild",
+					Invoke(func(ctx AppContext) { grandchildCtx = ctx }),
+				),
+			),
+			Invoke(func(ctx AppContext, got *A) {
+				rootCtx = ctx
+				a = got
+			}),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, "", ModuleNameFromContext(rootCtx))
+		assert.Equal(t, "child", ModuleNameFromContext(childCtx))
+		assert.Equal(t, "grandchild", ModuleNameFromContext(grandchildCtx))
+		assert.Equal(t, "child", ModuleNameFromContext(a.ctx),
+			"constructors get the context of the module that provided them")
+		assert.Equal(t, AppNameFromContext(rootCtx), AppNameFromContext(grandchildCtx))
+		assert.ErrorIs(t, grandchildCtx.Err(), context.Canceled)
+	})
+
+	t.Run("values from NewContext", func(t *testing.T) {
+		t.Parallel()
+
+		type ctxKey struct{}
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "foo"))
+
+		var appCtx AppContext
+		NewContext(ctx,
+			fxtest.WithTestLogger(t),
+			Invoke(func(ctx AppContext) { appCtx = ctx }),
+		)
+		cancel()
+
+		assert.Equal(t, "foo", appCtx.Value(ctxKey{}))
+		assert.NoError(t, appCtx.Err(),
+			"must not be cancelled with the construction context")
+	})
+
+	t.Run("not an AppContext", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, AppNameFromContext(context.Background()))
+		assert.Empty(t, ModuleNameFromContext(context.Background()))
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/fxtest"
+)
+
+// largeGraph builds an application with the given number of provides,
+// spread evenly across a chain of modules nested depth levels deep.
+//
+// Each constructor consumes the value produced by the previous one,
+// so building the last value runs all of them,
+// and also contributes to a value group consumed at the end.
+func largeGraph(depth, provides int) fx.Option {
+	perModule := provides / depth
+	n := 0
+	var build func(level int) fx.Option
+	build = func(level int) fx.Option {
+		opts := make([]fx.Option, 0, perModule+1)
+		for i := 0; i < perModule; i++ {
+			opts = append(opts, fx.Provide(
+				fx.Annotate(
+					func(v int) int { return v + 1 },
+					fx.ParamTags(fmt.Sprintf(`name:"v%d"`, n)),
+					fx.ResultTags(fmt.Sprintf(`name:"v%d"`, n+1)),
+				),
+				fx.Annotate(
+					func(v int) int { return v },
+					fx.ParamTags(fmt.Sprintf(`name:"v%d"`, n+1)),
+					fx.ResultTags(`group:"all"`),
+				),
+			))
+			n++
+		}
+		if level+1 < depth {
+			opts = append(opts, build(level+1))
+		}
+		return fx.Module(fmt.Sprintf("level%d", level), opts...)
+	}
+
+	tree := build(0)
+	last := fmt.Sprintf(`name:"v%d"`, n)
+	return fx.Options(
+		fx.Supply(fx.Annotated{Name: "v0", Target: 0}),
+		tree,
+		fx.Invoke(fx.Annotate(
+			func(last int, all []int) {},
+			fx.ParamTags(last, `group:"all"`),
+		)),
+	)
+}
+
+func BenchmarkLargeGraph(b *testing.B) {
+	for _, size := range []struct{ depth, provides int }{
+		{1, 2000},
+		{20, 2000},
+		{200, 2000},
+	} {
+		b.Run(fmt.Sprintf("depth=%d/provides=%d", size.depth, size.provides), func(b *testing.B) {
+			fxtest.BenchmarkApp(b, largeGraph(size.depth, size.provides))
+		})
+	}
+
+	b.Run("logging", func(b *testing.B) {
+		fxtest.BenchmarkApp(b,
+			fx.WithLogger(func() fxevent.Logger {
+				return &fxevent.ConsoleLogger{W: io.Discard}
+			}),
+			largeGraph(20, 2000),
+		)
+	})
+}
+
+// TestLargeGraph verifies that the benchmarked application is valid.
+func TestLargeGraph(t *testing.T) {
+	t.Parallel()
+
+	var got int
+	app := fxtest.New(t,
+		fx.NopLogger,
+		largeGraph(5, 50),
+		fx.Invoke(fx.Annotate(
+			func(v int) { got = v },
+			fx.ParamTags(`name:"v50"`),
+		)),
+	)
+	app.RequireStart().RequireStop()
+	assert.Equal(t, 50, got)
+}
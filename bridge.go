@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/fx/internal/fxclock"
+	"go.uber.org/fx/internal/fxdeps"
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+func init() {
+	// Lets fxtest reach these without making them part of this package's API.
+	fxbridge.Register(fxbridge.Funcs{
+		Clock: func(app interface{}) fxclock.Clock {
+			return app.(*App).clock
+		},
+		WithClock: func(clock fxclock.Clock) interface{} {
+			return withClockOption{clock}
+		},
+		InterceptHooks: func(intercept lifecycle.Interceptor) interface{} {
+			return interceptHooksOption{intercept}
+		},
+		RecordShutdowns: func(record func(interface{})) interface{} {
+			return recordShutdownsOption(func(sig ShutdownSignal) { record(sig) })
+		},
+		LifecycleHook: func(hook interface{}) lifecycle.Hook {
+			return hook.(Hook).lifecycleHook()
+		},
+		CollectFuncs: func(opt interface{}) (fxdeps.Funcs, error) {
+			return collectFuncs(opt.(Option))
+		},
+		DefaultKeys: defaultKeys,
+		GraphSnapshot: func(app interface{}) string {
+			return app.(*App).graphSnapshot()
+		},
+		GraphFuncs: func(app interface{}) []fxbridge.Func {
+			return app.(*App).graphFuncs()
+		},
+		ReplacedKeys: replacedKeys,
+		GraphConsumers: func(app interface{}, key fxdeps.Key, scope interface{}) []fxbridge.Func {
+			return app.(*App).graphConsumers(key, scope.(*module))
+		},
+		TestApp: func(onOption func(value, scope interface{})) interface{} {
+			return testAppOption(onOption)
+		},
+		TestOption: func(name string, value interface{}) interface{} {
+			return testOption{name: name, value: value}
+		},
+	})
+}
+
+// testAppOption marks an application as built by fxtest.New,
+// which receives the values of testOptions with the function,
+// along with the module they were given to.
+type testAppOption func(value, scope interface{})
+
+func (o testAppOption) apply(m *module) {
+	m.app.onTestOption = o
+}
+
+func (o testAppOption) String() string {
+	return "fxtest.New()"
+}
+
+// testOption is an option from package fxtest,
+// such as fxtest.VerifyNoLeaks, that fxtest.New needs to know about.
+// It works even if it's nested in fx.Options or fx.Module.
+type testOption struct {
+	name  string
+	value interface{}
+}
+
+func (o testOption) apply(m *module) {
+	if m.app.onTestOption == nil {
+		m.app.err = fmt.Errorf("%v Option should be passed to fxtest.New, not to fx.New", o.name)
+		return
+	}
+	m.app.onTestOption(o.value, m)
+}
+
+func (o testOption) String() string {
+	return o.name + "()"
+}
@@ -39,13 +39,23 @@ type broadcaster struct {
 	// nil if none, if a new channel is created by Wait or Done, this last
 	// signal will be immediately written to, this allows Wait or Done state
 	// to be read after application stop
-	last *ShutdownSignal
+	last *sourcedSignal
 
 	// contains channels created by Done
 	done []chan os.Signal
 
 	// contains channels created by Wait
 	wait []chan ShutdownSignal
+
+	// contains channels created by WaitSourced
+	waitSourced []chan sourcedSignal
+}
+
+// sourcedSignal is a ShutdownSignal along with where it came from.
+// They're sent together so that concurrent signals can't mix them up.
+type sourcedSignal struct {
+	signal ShutdownSignal
+	source shutdownSource
 }
 
 func (b *broadcaster) reset() {
@@ -69,7 +79,7 @@ func (b *broadcaster) Done() <-chan os.Signal {
 	// However we still want to have the operating system notify signals to this
 	// channel should the application receive another.
 	if b.last != nil {
-		ch <- b.last.Signal
+		ch <- b.last.signal.Signal
 	}
 	b.done = append(b.done, ch)
 	return ch
@@ -87,26 +97,43 @@ func (b *broadcaster) Wait() <-chan ShutdownSignal {
 	ch := make(chan ShutdownSignal, 1)
 
 	if b.last != nil {
-		ch <- *b.last
+		ch <- b.last.signal
 	}
 
 	b.wait = append(b.wait, ch)
 	return ch
 }
 
-// Broadcast sends the given signal to all channels that have been created
-// via Done or Wait. It does not block on sending, and returns an unsentSignalError
-// if any send did not go through.
-func (b *broadcaster) Broadcast(signal ShutdownSignal) error {
+// WaitSourced is like Wait, but the channel also receives
+// where each signal came from.
+func (b *broadcaster) WaitSourced() <-chan sourcedSignal {
 	b.m.Lock()
 	defer b.m.Unlock()
 
-	b.last = &signal
+	ch := make(chan sourcedSignal, 1)
+
+	if b.last != nil {
+		ch <- *b.last
+	}
+
+	b.waitSourced = append(b.waitSourced, ch)
+	return ch
+}
+
+// Broadcast sends the given signal, which came from src, to all channels
+// that have been created via Done or Wait. It does not block on sending,
+// and returns an unsentSignalError if any send did not go through.
+func (b *broadcaster) Broadcast(signal ShutdownSignal, src shutdownSource) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.last = &sourcedSignal{signal: signal, source: src}
 
 	channels, unsent := b.broadcast(
-		signal,
+		*b.last,
 		b.broadcastDone,
 		b.broadcastWait,
+		b.broadcastWaitSourced,
 	)
 
 	if unsent != 0 {
@@ -121,8 +148,8 @@ func (b *broadcaster) Broadcast(signal ShutdownSignal) error {
 }
 
 func (b *broadcaster) broadcast(
-	signal ShutdownSignal,
-	anchors ...func(ShutdownSignal) (int, int),
+	signal sourcedSignal,
+	anchors ...func(sourcedSignal) (int, int),
 ) (int, int) {
 	var channels, unsent int
 
@@ -135,12 +162,12 @@ func (b *broadcaster) broadcast(
 	return channels, unsent
 }
 
-func (b *broadcaster) broadcastDone(signal ShutdownSignal) (int, int) {
+func (b *broadcaster) broadcastDone(signal sourcedSignal) (int, int) {
 	var unsent int
 
 	for _, reader := range b.done {
 		select {
-		case reader <- signal.Signal:
+		case reader <- signal.signal.Signal:
 		default:
 			unsent++
 		}
@@ -149,12 +176,12 @@ func (b *broadcaster) broadcastDone(signal ShutdownSignal) (int, int) {
 	return len(b.done), unsent
 }
 
-func (b *broadcaster) broadcastWait(signal ShutdownSignal) (int, int) {
+func (b *broadcaster) broadcastWait(signal sourcedSignal) (int, int) {
 	var unsent int
 
 	for _, reader := range b.wait {
 		select {
-		case reader <- signal:
+		case reader <- signal.signal:
 		default:
 			unsent++
 		}
@@ -163,6 +190,20 @@ func (b *broadcaster) broadcastWait(signal ShutdownSignal) (int, int) {
 	return len(b.wait), unsent
 }
 
+func (b *broadcaster) broadcastWaitSourced(signal sourcedSignal) (int, int) {
+	var unsent int
+
+	for _, reader := range b.waitSourced {
+		select {
+		case reader <- signal:
+		default:
+			unsent++
+		}
+	}
+
+	return len(b.waitSourced), unsent
+}
+
 type unsentSignalError struct {
 	Signal ShutdownSignal
 	Unsent int
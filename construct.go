@@ -0,0 +1,246 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NewContext is like [New], but it stops constructing the application
+// when ctx is done.
+//
+// ctx is provided to constructors and invoked functions as a
+// [ConstructContext], so that they can give up on slow operations, such as
+// a DNS lookup, along with the rest of the application.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	app := fx.NewContext(ctx, opts...)
+//	if err := app.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// If ctx is done before the application is constructed, NewContext returns
+// without waiting for the function that was running at the time.
+// [App.Err] reports which function that was.
+// The function keeps running in the background until it returns,
+// so constructors should return when ctx is done.
+// Fx ignores what happens in the background after NewContext returns:
+// no more events are logged, lifecycle hooks appended by
+// the function are dropped, and no further invoked functions are run.
+//
+// Custom loggers given to [WithLogger] are built before ctx is checked,
+// so their constructors aren't bounded by ctx.
+func NewContext(ctx context.Context, opts ...Option) *App {
+	return newApp(ctx, opts)
+}
+
+// ConstructContext is a [context.Context] that's done when the application
+// should stop being constructed: when the context passed to [NewContext]
+// is done, or when the [ConstructTimeout] passes.
+// It's provided to applications built with either of them.
+//
+// It's only meant to be used during construction; use OnStart and OnStop
+// hooks, or [AppContext], for operations that need a context afterwards.
+//
+//	func NewResolver(ctx fx.ConstructContext) (*Resolver, error) {
+//		addrs, err := net.DefaultResolver.LookupHost(ctx, "example.com")
+//		...
+//	}
+type ConstructContext context.Context
+
+// ConstructTimeout is an [Option] that limits how long the application
+// may take to be constructed, like calling [NewContext] with a context
+// that has a timeout.
+// The timeout applies to all constructors, decorators, and invoked
+// functions together.
+//
+//	fx.New(
+//		fx.ConstructTimeout(10*time.Second),
+//		...
+//	)
+func ConstructTimeout(v time.Duration) Option {
+	return constructTimeoutOption(v)
+}
+
+type constructTimeoutOption time.Duration
+
+func (t constructTimeoutOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.ConstructTimeout Option should be passed to top-level App, " +
+			"not to fx.Module")
+	} else {
+		m.app.constructTimeout = time.Duration(t)
+	}
+}
+
+func (t constructTimeoutOption) String() string {
+	return fmt.Sprintf("fx.ConstructTimeout(%v)", time.Duration(t))
+}
+
+// runningFunc is a constructor, decorator, or invoked function
+// that's running during construction.
+type runningFunc struct {
+	kind       string // "provide", "decorate", or "invoke"
+	name       string
+	moduleName string
+}
+
+func (f runningFunc) String() string {
+	var kind string
+	switch f.kind {
+	case "provide":
+		kind = "constructor"
+	case "decorate":
+		kind = "decorator"
+	default:
+		kind = "fx.Invoke"
+	}
+	if f.moduleName != "" {
+		return fmt.Sprintf("%v %q from module %q", kind, f.name, f.moduleName)
+	}
+	return fmt.Sprintf("%v %q", kind, f.name)
+}
+
+// runningFuncs keeps track of the functions that are running during
+// construction. Constructors run inside invoked functions,
+// so the last function is the one that's actually running.
+type runningFuncs struct {
+	mu    sync.Mutex
+	funcs []runningFunc
+}
+
+// push records that f started running.
+// The returned function must be called when f returns.
+func (r *runningFuncs) push(f runningFunc) (pop func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.funcs = append(r.funcs, f)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.funcs = r.funcs[:len(r.funcs)-1]
+	}
+}
+
+// last returns the function that's running, if any.
+func (r *runningFuncs) last() (runningFunc, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.funcs) == 0 {
+		return runningFunc{}, false
+	}
+	return r.funcs[len(r.funcs)-1], true
+}
+
+// constructGuard keeps the goroutine that constructs an application
+// from changing it or logging events once construction has been abandoned.
+type constructGuard struct {
+	mu        sync.Mutex
+	abandoned bool
+}
+
+// do runs f unless construction has been abandoned.
+// Everything that construction does to the App,
+// other than running user functions, must go through do.
+func (g *constructGuard) do(f func()) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.abandoned {
+		f()
+	}
+}
+
+// isAbandoned reports whether construction has been abandoned.
+func (g *constructGuard) isAbandoned() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.abandoned
+}
+
+// abandon makes further calls to do no-ops.
+// It waits for the current call to do, if any, to return.
+func (g *constructGuard) abandon() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.abandoned = true
+}
+
+// errConstructAbandoned is returned by the goroutine that constructs an
+// application if it stops because construction has been abandoned.
+// Nothing receives it: NewContext has already returned.
+var errConstructAbandoned = errors.New("construction abandoned")
+
+// constructAbortedError is returned when the construction context
+// is done before the application is constructed.
+type constructAbortedError struct {
+	running    runningFunc
+	hasRunning bool
+	err        error
+}
+
+func (e *constructAbortedError) Error() string {
+	if !e.hasRunning {
+		return fmt.Sprintf("construction aborted: %v", e.err)
+	}
+	return fmt.Sprintf("construction aborted while %v was running: %v", e.running, e.err)
+}
+
+func (e *constructAbortedError) Unwrap() error {
+	return e.err
+}
+
+// invokeAll runs the invoked functions of the application.
+// If the construction context is done first,
+// it returns without waiting for them.
+func (app *App) invokeAll() error {
+	ctx := app.constructCtx
+	if ctx == nil {
+		return app.root.invokeAll()
+	}
+	if err := ctx.Err(); err != nil {
+		return &constructAbortedError{err: err}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- app.root.invokeAll()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		app.construct.abandon()
+		running, ok := app.running.last()
+		return &constructAbortedError{
+			running:    running,
+			hasRunning: ok,
+			err:        ctx.Err(),
+		}
+	}
+}
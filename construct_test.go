@@ -0,0 +1,235 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/fxtest"
+	"go.uber.org/fx/internal/fxclock"
+	"go.uber.org/fx/internal/fxlog"
+)
+
+func TestNewContext(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type ctxKey struct{}
+
+	t.Run("provides context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "foo")
+		var got any
+		app := NewContext(ctx,
+			fxtest.WithTestLogger(t),
+			Provide(func(ctx ConstructContext) *A {
+				got = ctx.Value(ctxKey{})
+				return &A{}
+			}),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, app.Err())
+		assert.Equal(t, "foo", got)
+	})
+
+	t.Run("hanging constructor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		release := make(chan struct{})
+		finished := make(chan struct{})
+		app := NewContext(ctx,
+			NopLogger,
+			Module("dns",
+				Provide(func() *A {
+					cancel()
+					<-release
+					return &A{}
+				}),
+			),
+			Invoke(func(*A) { close(finished) }),
+		)
+
+		// The constructor keeps running in the background.
+		close(release)
+		<-finished
+
+		err := app.Err()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, "while constructor")
+		assert.ErrorContains(t, err, `from module "dns" was running`)
+		assert.ErrorContains(t, err, "TestNewContext")
+	})
+
+	t.Run("application provides context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "foo")
+		var got any
+		app := NewContext(ctx,
+			fxtest.WithTestLogger(t),
+			Provide(func() context.Context {
+				return context.WithValue(context.Background(), ctxKey{}, "bar")
+			}),
+			Invoke(func(ctx context.Context) { got = ctx.Value(ctxKey{}) }),
+		)
+		require.NoError(t, app.Err())
+		assert.Equal(t, "bar", got)
+	})
+
+	t.Run("abandoned constructor", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		spy := new(fxlog.Spy)
+		release := make(chan struct{})
+		finished := make(chan struct{})
+		app := NewContext(ctx,
+			WithLogger(func() fxevent.Logger { return spy }),
+			Provide(func(lc Lifecycle) *A {
+				cancel()
+				<-release
+				lc.Append(Hook{OnStart: func(context.Context) error {
+					assert.Fail(t, "hook must not run")
+					return nil
+				}})
+				return &A{}
+			}),
+			Invoke(func(*A) { close(finished) }),
+		)
+		require.Error(t, app.Err())
+		events := spy.EventTypes()
+
+		close(release)
+		<-finished
+		assert.Equal(t, events, spy.EventTypes(),
+			"no events should be logged once construction is abandoned")
+		assert.Error(t, app.Start(context.Background()))
+	})
+
+	t.Run("hanging invoke", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		release := make(chan struct{})
+		finished := make(chan struct{})
+		app := NewContext(ctx,
+			NopLogger,
+			Invoke(func() {
+				defer close(finished)
+				cancel()
+				<-release
+			}),
+		)
+		close(release)
+		<-finished
+
+		err := app.Err()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorContains(t, err, `while fx.Invoke "go.uber.org/fx_test.TestNewContext`)
+	})
+
+	t.Run("context already done", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		app := NewContext(ctx,
+			NopLogger,
+			Invoke(func() {
+				assert.Fail(t, "invoke must not run")
+			}),
+		)
+		assert.EqualError(t, app.Err(), "construction aborted: context canceled")
+	})
+}
+
+func TestConstructTimeout(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+
+		clock := fxclock.NewMock()
+		release := make(chan struct{})
+		finished := make(chan struct{})
+		app := New(
+			NopLogger,
+			WithClock(clock),
+			ConstructTimeout(time.Second),
+			Provide(func(ctx ConstructContext) *A {
+				clock.Add(2 * time.Second)
+				<-release
+				return &A{}
+			}),
+			Invoke(func(*A) { close(finished) }),
+		)
+		close(release)
+		<-finished
+
+		err := app.Err()
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.ErrorContains(t, err, "while constructor")
+	})
+
+	t.Run("within timeout", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t,
+			ConstructTimeout(time.Minute),
+			Provide(func(ctx ConstructContext) *A {
+				assert.NoError(t, ctx.Err())
+				return &A{}
+			}),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, app.Err())
+	})
+
+	t.Run("in module", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(
+			NopLogger,
+			Module("child", ConstructTimeout(time.Second)),
+		)
+		assert.ErrorContains(t, app.Err(), "fx.ConstructTimeout Option should be passed to top-level App")
+	})
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"go.uber.org/fx/internal/fxreflect"
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+// DebugHandler is an [http.Handler] that reports the internal state of a
+// running Fx application. It is provided in the container by default,
+// and may be mounted on any mux.
+//
+//	fx.Invoke(func(mux *http.ServeMux, h fx.DebugHandler) {
+//		mux.Handle("/debug/fx/", h)
+//	})
+//
+// The endpoint is selected by the last element of the request path,
+// so the handler works with or without [http.StripPrefix].
+//
+//	state    state of the application and its initialization error
+//	graph    dependency graph in DOT, or JSON with ?format=json
+//	hooks    lifecycle hooks, where they were appended, and their runtimes
+//	modules  tree of modules with their provides, decorators, and invokes
+//
+// All endpoints except the DOT graph respond with JSON.
+// Any other path responds with a list of the endpoints.
+//
+// Note that the handler exposes function names and source locations
+// of the application. Do not mount it on a publicly reachable mux.
+type DebugHandler struct {
+	app *App
+}
+
+var _ http.Handler = DebugHandler{}
+
+func (app *App) debugHandler() DebugHandler {
+	return DebugHandler{app: app}
+}
+
+// ServeHTTP implements [http.Handler].
+func (h DebugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch path.Base(r.URL.Path) {
+	case "state":
+		h.serveJSON(w, h.state())
+	case "graph":
+		if r.URL.Query().Get("format") == "json" {
+			h.serveJSON(w, h.graph())
+			return
+		}
+		h.serveDOT(w)
+	case "hooks":
+		h.serveJSON(w, h.hooks())
+	case "modules":
+		h.serveJSON(w, h.app.root.debugInfo())
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		io.WriteString(w, "state\ngraph\ngraph?format=json\nhooks\nmodules\n")
+	}
+}
+
+func (h DebugHandler) serveJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h DebugHandler) serveDOT(w http.ResponseWriter) {
+	g, err := h.app.dotGraph()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+	io.WriteString(w, string(g))
+}
+
+type debugState struct {
+	State        string `json:"state"`
+	Err          string `json:"err,omitempty"`
+	StartTimeout string `json:"startTimeout"`
+	StopTimeout  string `json:"stopTimeout"`
+}
+
+func (h DebugHandler) state() debugState {
+	s := debugState{
+		State:        h.app.lifecycle.State(),
+		StartTimeout: h.app.StartTimeout().String(),
+		StopTimeout:  h.app.StopTimeout().String(),
+	}
+	if err := h.app.Err(); err != nil {
+		s.Err = err.Error()
+	}
+	return s
+}
+
+type debugConstructor struct {
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"`
+	Module   string   `json:"module,omitempty"`
+	Location string   `json:"location"`
+	Private  bool     `json:"private,omitempty"`
+	Inputs   []string `json:"inputs"`
+	Outputs  []string `json:"outputs"`
+}
+
+type debugGraph struct {
+	Constructors []debugConstructor `json:"constructors"`
+}
+
+func (h DebugHandler) graph() debugGraph {
+	g := debugGraph{
+		Constructors: make([]debugConstructor, len(h.app.constructors)),
+	}
+	for i, c := range h.app.constructors {
+		g.Constructors[i] = debugConstructor{
+			Name:     c.Name,
+			Kind:     c.Kind,
+			Module:   c.ModuleName,
+			Location: c.Location.String(),
+			Private:  c.Private,
+			Inputs:   c.Inputs,
+			Outputs:  c.Outputs,
+		}
+	}
+	return g
+}
+
+type debugHook struct {
+	OnStart string `json:"onStart,omitempty"`
+	OnStop  string `json:"onStop,omitempty"`
+	Caller  string `json:"caller"`
+	Started bool   `json:"started"`
+}
+
+type debugHookRecord struct {
+	Name    string `json:"name"`
+	Caller  string `json:"caller"`
+	Runtime string `json:"runtime"`
+}
+
+type debugHooks struct {
+	Hooks   []debugHook       `json:"hooks"`
+	OnStart []debugHookRecord `json:"onStart"`
+	OnStop  []debugHookRecord `json:"onStop"`
+}
+
+func (h DebugHandler) hooks() debugHooks {
+	lc := h.app.lifecycle.Lifecycle
+	infos := lc.Hooks()
+
+	hooks := debugHooks{
+		Hooks:   make([]debugHook, len(infos)),
+		OnStart: newDebugHookRecords(lc.StartRecords()),
+		OnStop:  newDebugHookRecords(lc.StopRecords()),
+	}
+	for i, info := range infos {
+		hooks.Hooks[i] = debugHook{
+			OnStart: info.OnStartName,
+			OnStop:  info.OnStopName,
+			Caller:  info.CallerFrame.String(),
+			Started: info.Started,
+		}
+	}
+	return hooks
+}
+
+func newDebugHookRecords(rs lifecycle.HookRecords) []debugHookRecord {
+	records := make([]debugHookRecord, len(rs))
+	for i, r := range rs {
+		records[i] = debugHookRecord{
+			Name:    r.Name,
+			Caller:  r.CallerFrame.String(),
+			Runtime: r.Runtime.String(),
+		}
+	}
+	return records
+}
+
+type debugModule struct {
+	Name       string        `json:"name"`
+	Location   string        `json:"location"`
+	Provides   []string      `json:"provides"`
+	Decorators []string      `json:"decorators"`
+	Invokes    []string      `json:"invokes"`
+	Modules    []debugModule `json:"modules"`
+}
+
+func (m *module) debugInfo() debugModule {
+	info := debugModule{
+		Name:       m.name,
+		Location:   m.location.String(),
+		Provides:   make([]string, len(m.provides)),
+		Decorators: make([]string, len(m.decorators)),
+		Invokes:    make([]string, len(m.invokes)),
+		Modules:    make([]debugModule, len(m.modules)),
+	}
+	for i, p := range m.provides {
+		if p.IsSupply {
+			info.Provides[i] = fmt.Sprintf("fx.Supply(%v)", p.SupplyType)
+		} else {
+			info.Provides[i] = fxreflect.FuncName(p.Target)
+		}
+	}
+	for i, d := range m.decorators {
+		if d.IsReplace {
+			info.Decorators[i] = fmt.Sprintf("fx.Replace(%v)", d.ReplaceType)
+		} else {
+			info.Decorators[i] = fxreflect.FuncName(d.Target)
+		}
+	}
+	for i, inv := range m.invokes {
+		info.Invokes[i] = fxreflect.FuncName(inv.Target)
+	}
+	for i, child := range m.modules {
+		info.Modules[i] = child.debugInfo()
+	}
+	return info
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestDebugHandler(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+
+	newApp := func(t *testing.T) (*fxtest.App, fx.DebugHandler) {
+		var h fx.DebugHandler
+		app := fxtest.New(t,
+			fx.Module("child",
+				fx.Provide(func() *A { return &A{} }),
+				fx.Supply(&B{}),
+			),
+			fx.Invoke(func(lc fx.Lifecycle, _ *A, _ *B) {
+				lc.Append(fx.StartStopHook(
+					func(context.Context) error { return nil },
+					func(context.Context) error { return nil },
+				))
+			}),
+			fx.Populate(&h),
+		)
+		return app, h
+	}
+
+	get := func(t *testing.T, h http.Handler, target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		require.Equal(t, http.StatusOK, rec.Code, "unexpected status: %s", rec.Body)
+		return rec
+	}
+
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder, v any) {
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+	}
+
+	t.Run("state", func(t *testing.T) {
+		t.Parallel()
+
+		app, h := newApp(t)
+
+		var state struct {
+			State        string `json:"state"`
+			StartTimeout string `json:"startTimeout"`
+		}
+		decode(t, get(t, h, "/debug/fx/state"), &state)
+		assert.Equal(t, "stopped", state.State)
+		assert.Equal(t, "15s", state.StartTimeout)
+
+		app.RequireStart()
+		decode(t, get(t, h, "/debug/fx/state"), &state)
+		assert.Equal(t, "started", state.State)
+		app.RequireStop()
+	})
+
+	t.Run("graph DOT", func(t *testing.T) {
+		t.Parallel()
+
+		_, h := newApp(t)
+
+		rec := get(t, h, "/debug/fx/graph")
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/vnd.graphviz")
+		assert.True(t, bytes.HasPrefix(rec.Body.Bytes(), []byte("digraph {")), "%s", rec.Body)
+	})
+
+	t.Run("graph JSON", func(t *testing.T) {
+		t.Parallel()
+
+		_, h := newApp(t)
+
+		var graph struct {
+			Constructors []struct {
+				Name    string   `json:"name"`
+				Kind    string   `json:"kind"`
+				Module  string   `json:"module"`
+				Outputs []string `json:"outputs"`
+			} `json:"constructors"`
+		}
+		decode(t, get(t, h, "/debug/fx/graph?format=json"), &graph)
+
+		var kinds []string
+		for _, c := range graph.Constructors {
+			if c.Module == "child" {
+				kinds = append(kinds, c.Kind)
+			}
+		}
+		assert.Equal(t, []string{"provide", "supply"}, kinds)
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		t.Parallel()
+
+		app, h := newApp(t)
+
+		var hooks struct {
+			Hooks []struct {
+				OnStart string `json:"onStart"`
+				Caller  string `json:"caller"`
+				Started bool   `json:"started"`
+			} `json:"hooks"`
+			OnStart []struct {
+				Name    string `json:"name"`
+				Runtime string `json:"runtime"`
+			} `json:"onStart"`
+		}
+		decode(t, get(t, h, "/debug/fx/hooks"), &hooks)
+		require.Len(t, hooks.Hooks, 1)
+		assert.False(t, hooks.Hooks[0].Started)
+		assert.Contains(t, hooks.Hooks[0].Caller, "TestDebugHandler")
+		assert.Empty(t, hooks.OnStart)
+
+		app.RequireStart()
+		defer app.RequireStop()
+
+		decode(t, get(t, h, "/debug/fx/hooks"), &hooks)
+		require.Len(t, hooks.Hooks, 1)
+		assert.True(t, hooks.Hooks[0].Started)
+		require.Len(t, hooks.OnStart, 1)
+		assert.Equal(t, hooks.Hooks[0].OnStart, hooks.OnStart[0].Name)
+		assert.NotEmpty(t, hooks.OnStart[0].Runtime)
+	})
+
+	t.Run("modules", func(t *testing.T) {
+		t.Parallel()
+
+		_, h := newApp(t)
+
+		type module struct {
+			Name     string   `json:"name"`
+			Provides []string `json:"provides"`
+			Invokes  []string `json:"invokes"`
+			Modules  []module `json:"modules"`
+		}
+		var root module
+		decode(t, get(t, h, "/debug/fx/modules"), &root)
+		assert.Len(t, root.Invokes, 2)
+		require.Len(t, root.Modules, 1)
+
+		child := root.Modules[0]
+		assert.Equal(t, "child", child.Name)
+		require.Len(t, child.Provides, 2)
+		assert.Equal(t, "fx.Supply(*fx_test.B)", child.Provides[1])
+	})
+
+	t.Run("index", func(t *testing.T) {
+		t.Parallel()
+
+		_, h := newApp(t)
+
+		rec := get(t, h, "/debug/fx/")
+		assert.Contains(t, rec.Body.String(), "modules")
+	})
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/dig"
+	"go.uber.org/fx/internal/fxdeps"
+	"go.uber.org/multierr"
+)
+
+// collectFuncs applies opt to a new module without building an App,
+// and returns the functions it would pass to Dig.
+func collectFuncs(opt Option) (fxdeps.Funcs, error) {
+	app := &App{}
+	m := &module{app: app}
+	opt.apply(m)
+	if app.err != nil {
+		return fxdeps.Funcs{}, app.err
+	}
+
+	var c funcCollector
+	c.collect(m, nil)
+	return c.funcs, c.err
+}
+
+// defaultKeys returns the values that Fx provides to applications,
+// including the [ConstructContext] of applications built with [NewContext].
+func defaultKeys() []fxdeps.Key {
+	app := NewContext(context.Background(), NopLogger)
+	var keys []fxdeps.Key
+	for _, c := range app.constructors {
+		if c.Default {
+			keys = append(keys, c.OutputKeys...)
+		}
+	}
+	return keys
+}
+
+// replacedKeys returns the values that fx.Replace replaces
+// when it's given values, resolving their annotations.
+func replacedKeys(values []interface{}) ([]fxdeps.Key, error) {
+	for _, v := range values {
+		if v, ok := v.(annotationError); ok {
+			return nil, fmt.Errorf("encountered error while applying annotation using fx.Annotate to %T: %w",
+				v.target, v.err)
+		}
+	}
+
+	m := &module{app: &App{}}
+	Replace(values...).apply(m)
+
+	var keys []fxdeps.Key
+	for _, d := range m.decorators {
+		if d, ok := d.Target.(annotated); ok {
+			if _, err := d.Build(); err != nil {
+				return nil, err
+			}
+		}
+		_, outs := decoratorDeps(d)
+		keys = append(keys, outs...)
+	}
+	return keys, nil
+}
+
+// funcCollector is a container that records the functions passed to it.
+type funcCollector struct {
+	funcs fxdeps.Funcs
+	err   error
+
+	// Module that the next functions are given to.
+	scope *fxdeps.Scope
+
+	// Name, group, and privacy of the next constructor.
+	name, group string
+	private     bool
+}
+
+var _ container = (*funcCollector)(nil)
+
+// collect records the functions of m and its descendants.
+// parent is the scope of the parent of m, if any.
+func (c *funcCollector) collect(m *module, parent *fxdeps.Scope) {
+	scope := &fxdeps.Scope{Parent: parent}
+	c.scope = scope
+	for _, p := range m.provides {
+		c.err = multierr.Append(c.err, c.collectProvide(p))
+	}
+	for _, i := range m.invokes {
+		c.err = multierr.Append(c.err, runInvoke(c, i))
+	}
+	for _, d := range m.decorators {
+		if d.IsReplace {
+			continue // replacements don't consume anything
+		}
+		c.err = multierr.Append(c.err, runDecorator(c, d))
+	}
+	if m.logConstructor != nil {
+		c.err = multierr.Append(c.err, runInvoke(c, invoke{
+			Target: m.logConstructor.Target,
+			Stack:  m.logConstructor.Stack,
+		}))
+	}
+	for _, child := range m.modules {
+		c.collect(child, scope)
+	}
+}
+
+// collectProvide records the constructor that p passes to Dig.
+func (c *funcCollector) collectProvide(p provide) error {
+	c.name, c.group, c.private = "", "", p.Private
+	if ann, ok := p.Target.(Annotated); ok {
+		c.name, c.group = ann.Name, ann.Group
+	}
+	return runProvide(c, p)
+}
+
+func (c *funcCollector) Provide(f interface{}, _ ...dig.ProvideOption) error {
+	c.funcs.Provides = append(c.funcs.Provides, fxdeps.Provide{
+		Func:    f,
+		Name:    c.name,
+		Group:   c.group,
+		Scope:   c.scope,
+		Private: c.private,
+	})
+	return nil
+}
+
+func (c *funcCollector) Invoke(f interface{}, _ ...dig.InvokeOption) error {
+	c.funcs.Consumers = append(c.funcs.Consumers, fxdeps.Consumer{Func: f, Scope: c.scope})
+	return nil
+}
+
+func (c *funcCollector) Decorate(f interface{}, _ ...dig.DecorateOption) error {
+	c.funcs.Consumers = append(c.funcs.Consumers, fxdeps.Consumer{Func: f, Scope: c.scope})
+	return nil
+}
+
+// provideDeps returns the values that p consumes and produces.
+func provideDeps(p provide) ([]fxdeps.Input, []fxdeps.Key) {
+	var c funcCollector
+	if err := c.collectProvide(p); err != nil || len(c.funcs.Provides) == 0 {
+		return nil, nil
+	}
+	ctor := c.funcs.Provides[0]
+	return fxdeps.Inputs(ctor.Func), fxdeps.Outputs(ctor)
+}
+
+// invokeInputs returns the values that i consumes.
+func invokeInputs(i invoke) []fxdeps.Input {
+	var c funcCollector
+	if err := runInvoke(&c, i); err != nil || len(c.funcs.Consumers) == 0 {
+		return nil
+	}
+	return fxdeps.Inputs(c.funcs.Consumers[0].Func)
+}
+
+// decoratorDeps returns the values that d consumes and decorates.
+func decoratorDeps(d decorator) ([]fxdeps.Input, []fxdeps.Key) {
+	var c funcCollector
+	if err := runDecorator(&c, d); err != nil || len(c.funcs.Consumers) == 0 {
+		return nil, nil
+	}
+	fn := c.funcs.Consumers[0].Func
+	return fxdeps.Inputs(fn), fxdeps.Outputs(fxdeps.Provide{Func: fn})
+}
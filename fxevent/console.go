@@ -36,12 +36,42 @@ type ConsoleLogger struct {
 
 var _ Logger = (*ConsoleLogger)(nil)
 
-func (l *ConsoleLogger) logf(msg string, args ...interface{}) {
-	fmt.Fprintf(l.W, "[Fx] "+msg+"\n", args...)
+// lineBuilder collects the lines that ConsoleLogger writes for an event.
+type lineBuilder []string
+
+func (l *lineBuilder) logf(msg string, args ...interface{}) {
+	*l = append(*l, fmt.Sprintf(msg, args...))
+}
+
+// logInputs logs the inputs of a constructor or decorator, if any,
+// under the lines for its outputs.
+func (l *lineBuilder) logInputs(inputs []string) {
+	if len(inputs) > 0 {
+		l.logf("\tneeds %v", strings.Join(inputs, ", "))
+	}
 }
 
-// LogEvent logs the given event to the provided Zap logger.
+// LogEvent logs the given event to the provided writer.
+// Events that [SeverityOf] reports as errors are marked with ERROR
+// on the line that reports the error, which is always their last.
 func (l *ConsoleLogger) LogEvent(event Event) {
+	lines := consoleLines(event)
+	if n := len(lines); n > 0 && SeverityOf(event) == SeverityError {
+		sep := "\t\t"
+		if strings.HasPrefix(lines[n-1], "\t") {
+			sep = "" // the line is already aligned
+		}
+		lines[n-1] = "ERROR" + sep + lines[n-1]
+	}
+	for _, line := range lines {
+		fmt.Fprintf(l.W, "[Fx] %s\n", line)
+	}
+}
+
+// consoleLines returns the lines that ConsoleLogger writes for event,
+// without their severity.
+func consoleLines(event Event) []string {
+	var l lineBuilder
 	switch e := event.(type) {
 	case *OnStartExecuting:
 		l.logf("HOOK OnStart\t\t%s executing (caller: %s)", e.FunctionName, e.CallerName)
@@ -59,9 +89,12 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 		} else {
 			l.logf("HOOK OnStop\t\t%s called by %s ran successfully in %s", e.FunctionName, e.CallerName, e.Runtime)
 		}
+	case *SlowHook:
+		l.logf("SLOW\t\t%s hook %s (caller: %s) has been running for %s, exceeding %s",
+			e.Method, e.FunctionName, e.CallerName, e.Runtime, e.Threshold)
 	case *Supplied:
 		if e.Err != nil {
-			l.logf("ERROR\tFailed to supply %v: %+v", e.TypeName, e.Err)
+			l.logf("\tFailed to supply %v: %+v", e.TypeName, e.Err)
 		} else if e.ModuleName != "" {
 			l.logf("SUPPLY\t%v from module %q", e.TypeName, e.ModuleName)
 		} else {
@@ -79,6 +112,7 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 				l.logf("PROVIDE%v\t%v <= %v", privateStr, rtype, e.ConstructorName)
 			}
 		}
+		l.logInputs(e.InputTypeNames)
 		if e.Err != nil {
 			l.logf("Error after options were applied: %+v", e.Err)
 		}
@@ -91,7 +125,7 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 			}
 		}
 		if e.Err != nil {
-			l.logf("ERROR\tFailed to replace: %+v", e.Err)
+			l.logf("\tFailed to replace: %+v", e.Err)
 		}
 	case *Decorated:
 		for _, rtype := range e.OutputTypeNames {
@@ -101,6 +135,7 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 				l.logf("DECORATE\t%v <= %v", rtype, e.DecoratorName)
 			}
 		}
+		l.logInputs(e.InputTypeNames)
 		if e.Err != nil {
 			l.logf("Error after options were applied: %+v", e.Err)
 		}
@@ -114,6 +149,13 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 			l.logf("Error returned: %+v", e.Err)
 		}
 
+	case *SlowConstructor:
+		var moduleStr string
+		if e.ModuleName != "" {
+			moduleStr = fmt.Sprintf(" from module %q", e.ModuleName)
+		}
+		l.logf("SLOW\t\t%v: %v%v has been running for %s, exceeding %s",
+			e.Kind, e.Name, moduleStr, e.Runtime, e.Threshold)
 	case *Invoking:
 		if e.ModuleName != "" {
 			l.logf("INVOKE\t\t%s from module %q", e.FunctionName, e.ModuleName)
@@ -122,31 +164,79 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 		}
 	case *Invoked:
 		if e.Err != nil {
-			l.logf("ERROR\t\tfx.Invoke(%v) called from:\n%+vFailed: %+v", e.FunctionName, e.Trace, e.Err)
+			l.logf("fx.Invoke(%v) called from:\n%+vFailed: %+v", e.FunctionName, e.Trace, e.Err)
+		}
+	case *ModuleBuilding:
+		l.logf("MODULE\t\t%q building", e.ModuleName)
+	case *ModuleBuilt:
+		if e.Err != nil {
+			l.logf("Failed to build module %q after %s: %+v", e.ModuleName, e.Runtime, e.Err)
+		} else {
+			l.logf("MODULE\t\t%q built in %s", e.ModuleName, e.Runtime)
+		}
+	case *ModuleHooksExecuting:
+		l.logf("MODULE\t\t%q executing %v hooks", e.ModuleName, e.Method)
+	case *ModuleHooksExecuted:
+		if e.Err != nil {
+			l.logf("%v hooks of module %q failed after %s: %+v", e.Method, e.ModuleName, e.Runtime, e.Err)
+		} else {
+			l.logf("MODULE\t\t%q executed %v hooks in %s", e.ModuleName, e.Method, e.Runtime)
 		}
 	case *Stopping:
-		l.logf("%v", strings.ToUpper(e.Signal.String()))
+		if e.Origin == "context" {
+			l.logf("SHUTDOWN\tcontext done")
+			break
+		}
+		if e.Origin != "shutdowner" {
+			l.logf("%v", strings.ToUpper(e.Signal.String()))
+			break
+		}
+		var exitCodeStr string
+		if e.ExitCode != 0 {
+			exitCodeStr = fmt.Sprintf(" with exit code %d", e.ExitCode)
+		}
+		l.logf("SHUTDOWN\trequested by %v%v", e.Caller, exitCodeStr)
+		if e.Failed {
+			l.logf("Shutting down because of: %+v", e.Reason)
+		} else if e.Reason != nil {
+			l.logf("Reason: %+v", e.Reason)
+		}
 	case *Stopped:
 		if e.Err != nil {
-			l.logf("ERROR\t\tFailed to stop cleanly: %+v", e.Err)
+			l.logf("Failed to stop cleanly: %+v", e.Err)
 		}
 	case *RollingBack:
-		l.logf("ERROR\t\tStart failed, rolling back: %+v", e.StartErr)
+		l.logf("Start failed, rolling back: %+v", e.StartErr)
 	case *RolledBack:
 		if e.Err != nil {
-			l.logf("ERROR\t\tCouldn't roll back cleanly: %+v", e.Err)
+			l.logf("Couldn't roll back cleanly: %+v", e.Err)
 		}
 	case *Started:
 		if e.Err != nil {
-			l.logf("ERROR\t\tFailed to start: %+v", e.Err)
+			l.logf("Failed to start: %+v", e.Err)
 		} else {
 			l.logf("RUNNING")
 		}
+	case *StartupSummary:
+		total := e.total()
+		l.logf("SUMMARY\t%d modules, %d provides, %d decorators, %d invokes, %d hooks; "+
+			"constructors ran in %s, OnStart hooks ran in %s; slowest constructors: %v; slowest hooks: %v",
+			len(e.Modules), total.Provides, total.Decorators, total.Invokes, total.Hooks,
+			e.ConstructorRuntime, e.OnStartRuntime,
+			joinFuncRuntimes(e.SlowestConstructors), joinFuncRuntimes(e.SlowestHooks))
 	case *LoggerInitialized:
 		if e.Err != nil {
-			l.logf("ERROR\t\tFailed to initialize custom logger: %+v", e.Err)
+			l.logf("Failed to initialize custom logger: %+v", e.Err)
 		} else {
 			l.logf("LOGGER\tInitialized custom logger from %v", e.ConstructorName)
 		}
 	}
+	return l
+}
+
+func joinFuncRuntimes(rs []FuncRuntime) string {
+	if len(rs) == 0 {
+		return "none"
+	}
+	return strings.Join(funcRuntimeStrings(rs), ", ")
 }
@@ -27,6 +27,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -78,7 +79,7 @@ func TestConsoleLogger(t *testing.T) {
 				CallerName:   "bytes.NewBuffer",
 				Err:          fmt.Errorf("some error"),
 			},
-			want: "[Fx] HOOK OnStop		hook.onStart1 called by bytes.NewBuffer failed in 0s: some error\n",
+			want: "[Fx] ERROR		HOOK OnStop		hook.onStart1 called by bytes.NewBuffer failed in 0s: some error\n",
 		},
 		{
 			name: "OnStopExecutedError/rich error",
@@ -87,7 +88,7 @@ func TestConsoleLogger(t *testing.T) {
 				CallerName:   "bytes.NewBuffer",
 				Err:          &richError{},
 			},
-			want: "[Fx] HOOK OnStop		hook.onStart1 called by bytes.NewBuffer failed in 0s: rich error\n",
+			want: "[Fx] ERROR		HOOK OnStop		hook.onStart1 called by bytes.NewBuffer failed in 0s: rich error\n",
 		},
 		{
 			name: "OnStopExecuted",
@@ -105,7 +106,7 @@ func TestConsoleLogger(t *testing.T) {
 				CallerName:   "bytes.NewBuffer",
 				Err:          fmt.Errorf("some error"),
 			},
-			want: "[Fx] HOOK OnStart		hook.onStart1 called by bytes.NewBuffer failed in 0s: some error\n",
+			want: "[Fx] ERROR		HOOK OnStart		hook.onStart1 called by bytes.NewBuffer failed in 0s: some error\n",
 		},
 		{
 			name: "OnStartExecutedError/rich error",
@@ -114,7 +115,7 @@ func TestConsoleLogger(t *testing.T) {
 				CallerName:   "bytes.NewBuffer",
 				Err:          &richError{},
 			},
-			want: "[Fx] HOOK OnStart		hook.onStart1 called by bytes.NewBuffer failed in 0s: rich error\n",
+			want: "[Fx] ERROR		HOOK OnStart		hook.onStart1 called by bytes.NewBuffer failed in 0s: rich error\n",
 		},
 		{
 			name: "OnStartExecuted",
@@ -128,12 +129,12 @@ func TestConsoleLogger(t *testing.T) {
 		{
 			name: "ProvideError",
 			give: &Provided{Err: errors.New("some error")},
-			want: "[Fx] Error after options were applied: some error\n",
+			want: "[Fx] ERROR\t\tError after options were applied: some error\n",
 		},
 		{
 			name: "ProvideError/rich error",
 			give: &Provided{Err: &richError{}},
-			want: "[Fx] Error after options were applied: rich error\n",
+			want: "[Fx] ERROR\t\tError after options were applied: rich error\n",
 		},
 		{
 			name: "Supplied",
@@ -210,6 +211,18 @@ func TestConsoleLogger(t *testing.T) {
 			},
 			want: "[Fx] PROVIDE (PRIVATE)	*bytes.Buffer <= bytes.NewBuffer() from module \"myModule\"\n",
 		},
+		{
+			name: "Provided with inputs",
+			give: &Provided{
+				ConstructorName: "bytes.NewBuffer()",
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				InputTypeNames:  []string{"[]uint8", `string[optional, name = "prefix"]`},
+				StackTrace:      []string{"main.main", "runtime.main"},
+				ModuleTrace:     []string{"main.main"},
+			},
+			want: "[Fx] PROVIDE	*bytes.Buffer <= bytes.NewBuffer()\n" +
+				"[Fx] 	needs []uint8, string[optional, name = \"prefix\"]\n",
+		},
 		{
 			name: "Replaced",
 			give: &Replaced{
@@ -255,15 +268,27 @@ func TestConsoleLogger(t *testing.T) {
 			},
 			want: "[Fx] DECORATE	*bytes.Buffer <= bytes.NewBuffer() from module \"myModule\"\n",
 		},
+		{
+			name: "Decorated with inputs",
+			give: &Decorated{
+				DecoratorName:   "bytes.NewBuffer()",
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				InputTypeNames:  []string{"*bytes.Buffer"},
+				StackTrace:      []string{"main.main", "runtime.main"},
+				ModuleTrace:     []string{"main.main"},
+			},
+			want: "[Fx] DECORATE	*bytes.Buffer <= bytes.NewBuffer()\n" +
+				"[Fx] 	needs *bytes.Buffer\n",
+		},
 		{
 			name: "DecorateError",
 			give: &Decorated{Err: errors.New("some error")},
-			want: "[Fx] Error after options were applied: some error\n",
+			want: "[Fx] ERROR\t\tError after options were applied: some error\n",
 		},
 		{
 			name: "DecorateError/rich error",
 			give: &Decorated{Err: &richError{}},
-			want: "[Fx] Error after options were applied: rich error\n",
+			want: "[Fx] ERROR\t\tError after options were applied: rich error\n",
 		},
 		{
 			name: "Run",
@@ -290,7 +315,7 @@ func TestConsoleLogger(t *testing.T) {
 			},
 			want: joinLines(
 				"[Fx] RUN\tconstructor: bytes.NewBuffer() in 5s",
-				"[Fx] Error returned: terrible constructor error",
+				"[Fx] ERROR\t\tError returned: terrible constructor error",
 			),
 		},
 		{
@@ -334,6 +359,61 @@ func TestConsoleLogger(t *testing.T) {
 				"Failed: rich error",
 			),
 		},
+		{
+			name: "ModuleBuilding",
+			give: &ModuleBuilding{ModuleName: "myModule"},
+			want: "[Fx] MODULE		\"myModule\" building\n",
+		},
+		{
+			name: "ModuleBuilt",
+			give: &ModuleBuilt{ModuleName: "myModule", Runtime: time.Millisecond},
+			want: "[Fx] MODULE		\"myModule\" built in 1ms\n",
+		},
+		{
+			name: "ModuleBuilt/Error",
+			give: &ModuleBuilt{ModuleName: "myModule", Runtime: time.Millisecond, Err: errors.New("some error")},
+			want: "[Fx] ERROR		Failed to build module \"myModule\" after 1ms: some error\n",
+		},
+		{
+			name: "ModuleHooksExecuting",
+			give: &ModuleHooksExecuting{ModuleName: "myModule", Method: "OnStart"},
+			want: "[Fx] MODULE		\"myModule\" executing OnStart hooks\n",
+		},
+		{
+			name: "ModuleHooksExecuted",
+			give: &ModuleHooksExecuted{ModuleName: "myModule", Method: "OnStop", Runtime: time.Millisecond},
+			want: "[Fx] MODULE		\"myModule\" executed OnStop hooks in 1ms\n",
+		},
+		{
+			name: "ModuleHooksExecuted/Error",
+			give: &ModuleHooksExecuted{ModuleName: "myModule", Method: "OnStart", Runtime: time.Millisecond, Err: errors.New("some error")},
+			want: "[Fx] ERROR		OnStart hooks of module \"myModule\" failed after 1ms: some error\n",
+		},
+		{
+			name: "Stopping/Shutdowner",
+			give: &Stopping{
+				Signal:   syscall.SIGTERM,
+				Origin:   "shutdowner",
+				Caller:   "main.main (main.go:42)",
+				ExitCode: 2,
+				Reason:   errors.New("great sadness"),
+			},
+			want: "[Fx] SHUTDOWN	requested by main.main (main.go:42) with exit code 2\n" +
+				"[Fx] Reason: great sadness\n",
+		},
+		{
+			name: "Stopping/ShutdownError",
+			give: &Stopping{
+				Signal:   syscall.SIGTERM,
+				Origin:   "shutdowner",
+				Caller:   "main.main (main.go:42)",
+				ExitCode: 1,
+				Reason:   errors.New("great sadness"),
+				Failed:   true,
+			},
+			want: "[Fx] SHUTDOWN	requested by main.main (main.go:42) with exit code 1\n" +
+				"[Fx] ERROR\t\tShutting down because of: great sadness\n",
+		},
 		{
 			name: "StartError",
 			give: &Started{Err: errors.New("some error")},
@@ -349,6 +429,11 @@ func TestConsoleLogger(t *testing.T) {
 			give: &Stopping{Signal: os.Interrupt},
 			want: "[Fx] INTERRUPT\n",
 		},
+		{
+			name: "Stopping/Context",
+			give: &Stopping{Origin: "context"},
+			want: "[Fx] SHUTDOWN\tcontext done\n",
+		},
 		{
 			name: "Stopped",
 			give: &Stopped{Err: errors.New("some error")},
@@ -394,6 +479,57 @@ func TestConsoleLogger(t *testing.T) {
 			give: &LoggerInitialized{Err: &richError{}},
 			want: "[Fx] ERROR		Failed to initialize custom logger: rich error\n",
 		},
+		{
+			name: "SlowHook",
+			give: &SlowHook{
+				FunctionName: "hook.onStart",
+				CallerName:   "bytes.NewBuffer",
+				Method:       "OnStart",
+				Threshold:    time.Second,
+				Runtime:      2 * time.Second,
+			},
+			want: "[Fx] SLOW\t\tOnStart hook hook.onStart (caller: bytes.NewBuffer) has been running for 2s, exceeding 1s\n",
+		},
+		{
+			name: "SlowConstructor",
+			give: &SlowConstructor{
+				Name:       "bytes.NewBuffer()",
+				Kind:       "provide",
+				ModuleName: "myModule",
+				Threshold:  time.Second,
+				Runtime:    2 * time.Second,
+			},
+			want: "[Fx] SLOW\t\tprovide: bytes.NewBuffer() from module \"myModule\" has been running for 2s, exceeding 1s\n",
+		},
+		{
+			name: "StartupSummary",
+			give: &StartupSummary{
+				Modules: []ModuleSummary{
+					{Provides: 2, Invokes: 1, Hooks: 1},
+					{ModuleName: "child", Provides: 1, Decorators: 1},
+				},
+				ConstructorRuntime: 3 * time.Millisecond,
+				OnStartRuntime:     time.Millisecond,
+				SlowestConstructors: []FuncRuntime{
+					{Name: "bytes.NewBuffer()", Runtime: 2 * time.Millisecond},
+					{Name: "strings.NewReader()", ModuleName: "child", Runtime: time.Millisecond},
+				},
+				SlowestHooks: []FuncRuntime{
+					{Name: "main.start()", Runtime: time.Millisecond},
+				},
+			},
+			want: "[Fx] SUMMARY\t2 modules, 3 provides, 1 decorators, 1 invokes, 1 hooks; " +
+				"constructors ran in 3ms, OnStart hooks ran in 1ms; " +
+				"slowest constructors: bytes.NewBuffer() (2ms), strings.NewReader() (1ms); " +
+				"slowest hooks: main.start() (1ms)\n",
+		},
+		{
+			name: "StartupSummary/empty",
+			give: &StartupSummary{Modules: []ModuleSummary{{}}},
+			want: "[Fx] SUMMARY\t1 modules, 0 provides, 0 decorators, 0 invokes, 0 hooks; " +
+				"constructors ran in 0s, OnStart hooks ran in 0s; " +
+				"slowest constructors: none; slowest hooks: none\n",
+		},
 		{
 			name: "LoggerInitialized",
 			give: &LoggerInitialized{ConstructorName: "go.uber.org/fx/fxevent.TestConsoleLogger.func1()"},
@@ -414,6 +550,71 @@ func TestConsoleLogger(t *testing.T) {
 	}
 }
 
+func TestConsoleLoggerSeverity(t *testing.T) {
+	t.Parallel()
+
+	someError := errors.New("some error")
+	events := []Event{
+		&OnStartExecuting{},
+		&OnStartExecuted{},
+		&OnStartExecuted{Err: someError},
+		&OnStopExecuting{},
+		&OnStopExecuted{},
+		&OnStopExecuted{Err: someError},
+		&SlowHook{},
+		&Supplied{},
+		&Supplied{Err: someError},
+		&Provided{OutputTypeNames: []string{"*bytes.Buffer"}},
+		&Provided{OutputTypeNames: []string{"*bytes.Buffer"}, Err: someError},
+		&Replaced{OutputTypeNames: []string{"*bytes.Buffer"}},
+		&Replaced{OutputTypeNames: []string{"*bytes.Buffer"}, Err: someError},
+		&Decorated{OutputTypeNames: []string{"*bytes.Buffer"}},
+		&Decorated{OutputTypeNames: []string{"*bytes.Buffer"}, Err: someError},
+		&Run{},
+		&Run{Err: someError},
+		&SlowConstructor{},
+		&Invoking{},
+		&Invoked{},
+		&Invoked{Err: someError},
+		&ModuleBuilding{},
+		&ModuleBuilt{},
+		&ModuleBuilt{Err: someError},
+		&ModuleHooksExecuting{},
+		&ModuleHooksExecuted{},
+		&ModuleHooksExecuted{Err: someError},
+		&Stopping{Signal: os.Interrupt},
+		&Stopping{Signal: syscall.SIGTERM, Origin: "shutdowner", Reason: someError},
+		&Stopping{Signal: syscall.SIGTERM, Origin: "shutdowner", Reason: someError, Failed: true},
+		&Stopping{Origin: "context"},
+		&Stopped{},
+		&Stopped{Err: someError},
+		&RollingBack{StartErr: someError},
+		&RolledBack{},
+		&RolledBack{Err: someError},
+		&Started{},
+		&Started{Err: someError},
+		&StartupSummary{},
+		&LoggerInitialized{},
+		&LoggerInitialized{Err: someError},
+	}
+
+	for _, e := range events {
+		e := e
+		sev := SeverityOf(e)
+		t.Run(fmt.Sprintf("%T/%v", e, sev), func(t *testing.T) {
+			t.Parallel()
+
+			var buff bytes.Buffer
+			(&ConsoleLogger{W: &buff}).LogEvent(e)
+			if sev == SeverityError {
+				assert.Contains(t, buff.String(), "[Fx] ERROR")
+			} else {
+				assert.NotContains(t, buff.String(), "ERROR")
+			}
+		})
+	}
+}
+
 func joinLines(lines ...string) string {
 	return strings.Join(lines, "\n") + "\n"
 }
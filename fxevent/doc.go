@@ -50,6 +50,30 @@
 //		),
 //	)
 //
+// To process events with other tools, use the [JSONLogger],
+// which writes one JSON object per line.
+// These can be read back with a [JSONDecoder].
+//
+// # Combining Loggers
+//
+// Use [Tee] to send events to more than one logger,
+// and [Filter] to send only some events to a logger.
+// [SeverityOf] classifies events the same way the built-in loggers do
+// (see [Severity]),
+// so [MinSeverity] may be used to route only warnings and errors.
+//
+//	fx.WithLogger(
+//		func(log *zap.Logger) fxevent.Logger {
+//			return fxevent.Tee(
+//				&fxevent.ZapLogger{Logger: log},
+//				fxevent.Filter(
+//					&fxevent.ConsoleLogger{W: os.Stderr},
+//					fxevent.MinSeverity(fxevent.SeverityError),
+//				),
+//			)
+//		},
+//	)
+//
 // # Implementing a Custom Logger
 //
 // To implement a custom logger, you need to implement the [Logger] interface.
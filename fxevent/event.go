@@ -21,6 +21,7 @@
 package fxevent
 
 import (
+	"fmt"
 	"os"
 	"time"
 )
@@ -31,23 +32,30 @@ type Event interface {
 }
 
 // Passing events by type to make Event hashable in the future.
-func (*OnStartExecuting) event()  {}
-func (*OnStartExecuted) event()   {}
-func (*OnStopExecuting) event()   {}
-func (*OnStopExecuted) event()    {}
-func (*Supplied) event()          {}
-func (*Provided) event()          {}
-func (*Replaced) event()          {}
-func (*Decorated) event()         {}
-func (*Run) event()               {}
-func (*Invoking) event()          {}
-func (*Invoked) event()           {}
-func (*Stopping) event()          {}
-func (*Stopped) event()           {}
-func (*RollingBack) event()       {}
-func (*RolledBack) event()        {}
-func (*Started) event()           {}
-func (*LoggerInitialized) event() {}
+func (*OnStartExecuting) event()     {}
+func (*OnStartExecuted) event()      {}
+func (*OnStopExecuting) event()      {}
+func (*OnStopExecuted) event()       {}
+func (*Supplied) event()             {}
+func (*Provided) event()             {}
+func (*Replaced) event()             {}
+func (*Decorated) event()            {}
+func (*Run) event()                  {}
+func (*Invoking) event()             {}
+func (*Invoked) event()              {}
+func (*Stopping) event()             {}
+func (*Stopped) event()              {}
+func (*RollingBack) event()          {}
+func (*RolledBack) event()           {}
+func (*Started) event()              {}
+func (*LoggerInitialized) event()    {}
+func (*StartupSummary) event()       {}
+func (*SlowHook) event()             {}
+func (*SlowConstructor) event()      {}
+func (*ModuleBuilding) event()       {}
+func (*ModuleBuilt) event()          {}
+func (*ModuleHooksExecuting) event() {}
+func (*ModuleHooksExecuted) event()  {}
 
 // OnStartExecuting is emitted before an OnStart hook is executed.
 type OnStartExecuting struct {
@@ -105,6 +113,29 @@ type OnStopExecuted struct {
 	Err error
 }
 
+// SlowHook is emitted when a lifecycle hook runs for longer than the
+// threshold set with fx.SlowThreshold. It is emitted as soon as the threshold
+// is exceeded, even if the hook is still running, and at most once per run.
+type SlowHook struct {
+	// FunctionName is the name of the hook function.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+
+	// Method specifies the kind of the hook. This is one of "OnStart" and
+	// "OnStop".
+	Method string
+
+	// Threshold is the threshold that the hook exceeded.
+	Threshold time.Duration
+
+	// Runtime specifies how long the hook had been running
+	// when this event was emitted.
+	Runtime time.Duration
+}
+
 // Supplied is emitted after a value is added with fx.Supply.
 type Supplied struct {
 	// TypeName is the name of the type of value that was added.
@@ -139,6 +170,13 @@ type Provided struct {
 	// this constructor.
 	OutputTypeNames []string
 
+	// InputTypeNames is a list of names of types that are consumed by
+	// this constructor. Each name includes the name or group tag of the
+	// input and whether it's optional, e.g.
+	//
+	//	*bytes.Buffer[optional, name = "buf"]
+	InputTypeNames []string
+
 	// ModuleName is the name of the module in which the constructor was
 	// provided to.
 	ModuleName string
@@ -187,6 +225,10 @@ type Decorated struct {
 	// this decorator.
 	OutputTypeNames []string
 
+	// InputTypeNames is a list of names of types that are consumed by
+	// this decorator, in the same format as [Provided.InputTypeNames].
+	InputTypeNames []string
+
 	// Err is non-nil if we failed to run this decorator.
 	Err error
 }
@@ -211,6 +253,29 @@ type Run struct {
 	Err error
 }
 
+// SlowConstructor is emitted when a constructor or decorator runs for longer
+// than the threshold set with fx.SlowThreshold. It is emitted as soon as the
+// threshold is exceeded, even if the function is still running, and at most
+// once per run.
+type SlowConstructor struct {
+	// Name is the name of the function.
+	Name string
+
+	// Kind indicates which Fx option was used to pass along the function.
+	// It is either "provide" or "decorate".
+	Kind string
+
+	// ModuleName is the name of the module in which the function belongs.
+	ModuleName string
+
+	// Threshold is the threshold that the function exceeded.
+	Threshold time.Duration
+
+	// Runtime specifies how long the function had been running
+	// when this event was emitted.
+	Runtime time.Duration
+}
+
 // Invoking is emitted before we invoke a function specified with fx.Invoke.
 type Invoking struct {
 	// FunctionName is the name of the function that will be invoked.
@@ -237,6 +302,64 @@ type Invoked struct {
 	Trace string
 }
 
+// ModuleBuilding is emitted before Fx runs the invokes of an fx.Module.
+type ModuleBuilding struct {
+	// ModuleName is the name of the module.
+	ModuleName string
+}
+
+// ModuleBuilt is emitted after Fx has run the invokes of an fx.Module
+// and of its submodules.
+type ModuleBuilt struct {
+	// ModuleName is the name of the module.
+	ModuleName string
+
+	// Runtime is how long Fx has spent running the constructors,
+	// decorators, and invokes of the module, not counting its submodules.
+	//
+	// Constructors are counted toward the module they were provided to,
+	// whichever module's invoke needed them. Constructors of the module
+	// that are first needed by a later invoke, e.g. one of the parent
+	// module, run after this event and aren't included.
+	Runtime time.Duration
+
+	// Err is non-nil if one of the invokes of the module or its submodules
+	// failed.
+	Err error
+}
+
+// ModuleHooksExecuting is emitted before the first OnStart or OnStop hook
+// of an fx.Module is executed.
+//
+// Hooks of different modules may be interleaved, so events for one module
+// may occur between the ModuleHooksExecuting and ModuleHooksExecuted events
+// of another.
+type ModuleHooksExecuting struct {
+	// ModuleName is the name of the module that appended the hooks.
+	ModuleName string
+
+	// Method is the hook method being executed: "OnStart" or "OnStop".
+	Method string
+}
+
+// ModuleHooksExecuted is emitted after the last OnStart or OnStop hook
+// of an fx.Module is executed, or after Fx stops executing them early.
+type ModuleHooksExecuted struct {
+	// ModuleName is the name of the module that appended the hooks.
+	ModuleName string
+
+	// Method is the hook method that was executed: "OnStart" or "OnStop".
+	Method string
+
+	// Runtime is the time spent executing the hooks of the module.
+	// It doesn't include the time spent in hooks of other modules.
+	Runtime time.Duration
+
+	// Err is non-nil if a hook of the module failed,
+	// or if its hooks were interrupted by another failure.
+	Err error
+}
+
 // Started is emitted when an application is started successfully and/or it
 // errored.
 type Started struct {
@@ -244,12 +367,121 @@ type Started struct {
 	Err error
 }
 
+// StartupSummary is emitted after an application has started successfully.
+// It summarizes the work that was done to build and start the application,
+// as reported by the other events.
+type StartupSummary struct {
+	// Modules summarizes each module in the application,
+	// starting with the root module, which has an empty name.
+	Modules []ModuleSummary
+
+	// ConstructorRuntime is the total time spent running constructors,
+	// decorators, and supply/replace stubs.
+	ConstructorRuntime time.Duration
+
+	// OnStartRuntime is the total time spent running OnStart hooks.
+	OnStartRuntime time.Duration
+
+	// SlowestConstructors lists the constructors, decorators,
+	// and supply/replace stubs that took the longest to run,
+	// starting with the slowest.
+	SlowestConstructors []FuncRuntime
+
+	// SlowestHooks lists the OnStart hooks that took the longest to run,
+	// starting with the slowest.
+	SlowestHooks []FuncRuntime
+}
+
+// ModuleSummary is the part of a [StartupSummary] about a single module.
+// It does not include the module's children.
+type ModuleSummary struct {
+	// ModuleName is the name of the module.
+	ModuleName string
+
+	// Provides is the number of constructors provided to the module,
+	// including values added with fx.Supply.
+	Provides int
+
+	// Decorators is the number of decorators given to the module,
+	// including values added with fx.Replace.
+	Decorators int
+
+	// Invokes is the number of functions invoked in the module.
+	Invokes int
+
+	// Hooks is the number of lifecycle hooks appended by constructors
+	// and invoked functions of the module.
+	Hooks int
+}
+
+// total sums the counts of all modules in the summary.
+// The ModuleName of the result is empty.
+func (e *StartupSummary) total() ModuleSummary {
+	var total ModuleSummary
+	for _, m := range e.Modules {
+		total.Provides += m.Provides
+		total.Decorators += m.Decorators
+		total.Invokes += m.Invokes
+		total.Hooks += m.Hooks
+	}
+	return total
+}
+
+// FuncRuntime reports how long a function run by Fx took.
+type FuncRuntime struct {
+	// Name is the name of the function.
+	Name string
+
+	// ModuleName is the name of the module the function belongs to.
+	ModuleName string
+
+	// Runtime specifies how long it took to run this function.
+	Runtime time.Duration
+}
+
+// String returns the name of the function and how long it took to run.
+func (r FuncRuntime) String() string {
+	return fmt.Sprintf("%v (%v)", r.Name, r.Runtime)
+}
+
+func funcRuntimeStrings(rs []FuncRuntime) []string {
+	strs := make([]string, len(rs))
+	for i, r := range rs {
+		strs[i] = r.String()
+	}
+	return strs
+}
+
 // Stopping is emitted when the application receives a signal to shut down
 // after starting. This may happen with fx.Shutdowner or by sending a signal to
 // the application on the command line.
 type Stopping struct {
 	// Signal is the signal that caused this shutdown.
+	// Shutdowns requested with fx.Shutdowner report SIGTERM.
 	Signal os.Signal
+
+	// Origin is how the shutdown was requested:
+	// "signal" if the application received a signal,
+	// "shutdowner" if fx.Shutdowner was used,
+	// or "context" if the context passed to App.RunContext is done.
+	// Signal is nil if Origin is "context".
+	Origin string
+
+	// Caller is the function and location that called
+	// fx.Shutdowner.Shutdown, if Origin is "shutdowner".
+	Caller string
+
+	// ExitCode is the exit code the application will exit with
+	// if it stops successfully.
+	ExitCode int
+
+	// Reason is the error passed to fx.ShutdownReason
+	// or fx.ShutdownError, if any.
+	Reason error
+
+	// Failed reports whether the application is shutting down because of
+	// an error, i.e. Reason was passed to fx.ShutdownError.
+	Failed bool
 }
 
 // Stopped is emitted when the application has finished shutting down, whether
@@ -48,6 +48,13 @@ func TestForCoverage(t *testing.T) {
 		&RolledBack{},
 		&Started{},
 		&LoggerInitialized{},
+		&StartupSummary{},
+		&SlowHook{},
+		&SlowConstructor{},
+		&ModuleBuilding{},
+		&ModuleBuilt{},
+		&ModuleHooksExecuting{},
+		&ModuleHooksExecuted{},
 	}
 
 	for _, e := range events {
@@ -0,0 +1,366 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// JSONLogger is an Fx event logger that writes each event
+// as a single line of JSON.
+//
+//	{"event":"Run","name":"main.NewServer()","kind":"provide","moduleName":"","runtime":1500000,"err":null}
+//
+// Each object has an "event" key with the name of the event type,
+// followed by a key for each field of the event, in the order they are
+// declared, named after the field in lowerCamelCase.
+// All fields are present, even if they're empty.
+//
+//   - Errors are written as their message, or null if nil.
+//   - Durations are written as an integer number of nanoseconds.
+//   - Signals are written by name, e.g. "interrupt", or null if nil.
+//
+// Use [JSONDecoder] to read the events back.
+type JSONLogger struct {
+	W io.Writer
+
+	mu sync.Mutex // serializes writes
+}
+
+var _ Logger = (*JSONLogger)(nil)
+
+// LogEvent writes the event to the logger's writer.
+func (l *JSONLogger) LogEvent(event Event) {
+	b, err := marshalEvent(event, time.Time{})
+	if err != nil {
+		// Only unknown events fail to marshal,
+		// and there aren't any outside this package.
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.W.Write(append(b, '\n'))
+}
+
+// JSONDecoder reads events written by [JSONLogger].
+type JSONDecoder struct {
+	dec *json.Decoder
+}
+
+// NewJSONDecoder builds a JSONDecoder that reads from r.
+func NewJSONDecoder(r io.Reader) *JSONDecoder {
+	return &JSONDecoder{dec: json.NewDecoder(r)}
+}
+
+// Decode reads the next event. It returns io.EOF if there are no more events.
+//
+// Errors in decoded events have the same message as the original errors,
+// but not their type, and signals are only usable by their name.
+// Keys that don't match a field of the event are ignored.
+func (d *JSONDecoder) Decode() (Event, error) {
+	rec, err := d.decodeRecord()
+	return rec.Event, err
+}
+
+// decodeRecord reads the next event along with its "time" key, if any.
+func (d *JSONDecoder) decodeRecord() (RecordedEvent, error) {
+	var obj map[string]json.RawMessage
+	if err := d.dec.Decode(&obj); err != nil {
+		return RecordedEvent{}, err
+	}
+
+	var rec RecordedEvent
+	if raw, ok := obj["time"]; ok {
+		if err := json.Unmarshal(raw, &rec.Time); err != nil {
+			return RecordedEvent{}, fmt.Errorf("read event time: %w", err)
+		}
+	}
+
+	e, err := unmarshalEvent(obj)
+	if err != nil {
+		return RecordedEvent{}, err
+	}
+	rec.Event = e
+	return rec, nil
+}
+
+// _jsonEventTypes maps names of event types to the types.
+var _jsonEventTypes = make(map[string]reflect.Type)
+
+func init() {
+	for _, e := range []Event{
+		&OnStartExecuting{},
+		&OnStartExecuted{},
+		&OnStopExecuting{},
+		&OnStopExecuted{},
+		&SlowHook{},
+		&Supplied{},
+		&Provided{},
+		&Replaced{},
+		&Decorated{},
+		&Run{},
+		&SlowConstructor{},
+		&Invoking{},
+		&Invoked{},
+		&ModuleBuilding{},
+		&ModuleBuilt{},
+		&ModuleHooksExecuting{},
+		&ModuleHooksExecuted{},
+		&Stopping{},
+		&Stopped{},
+		&RollingBack{},
+		&RolledBack{},
+		&Started{},
+		&StartupSummary{},
+		&LoggerInitialized{},
+	} {
+		t := reflect.TypeOf(e).Elem()
+		_jsonEventTypes[t.Name()] = t
+	}
+}
+
+var (
+	_durationType = reflect.TypeOf(time.Duration(0))
+	_errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	_signalType   = reflect.TypeOf((*os.Signal)(nil)).Elem()
+)
+
+// jsonKey returns the JSON key for a struct field.
+func jsonKey(name string) string {
+	r, n := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[n:]
+}
+
+// marshalEvent encodes event as a JSON object.
+// A "time" key is added after the event type if ts is non-zero.
+func marshalEvent(event Event, ts time.Time) ([]byte, error) {
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return nil, fmt.Errorf("unknown event %T", event)
+	}
+	name := v.Elem().Type().Name()
+	if _, ok := _jsonEventTypes[name]; !ok {
+		return nil, fmt.Errorf("unknown event %T", event)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"event":`)
+	buf.WriteString(strconv.Quote(name))
+	if !ts.IsZero() {
+		b, err := ts.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString(`,"time":`)
+		buf.Write(b)
+	}
+	if err := marshalFields(&buf, v.Elem()); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// marshalFields writes the fields of the struct v as ,"key":value pairs.
+func marshalFields(buf *bytes.Buffer, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		buf.WriteByte(',')
+		buf.WriteString(strconv.Quote(jsonKey(f.Name)))
+		buf.WriteByte(':')
+		if err := marshalValue(buf, v.Field(i)); err != nil {
+			return fmt.Errorf("field %v: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func marshalValue(buf *bytes.Buffer, v reflect.Value) error {
+	switch t := v.Type(); {
+	case t == _durationType:
+		buf.WriteString(strconv.FormatInt(v.Int(), 10))
+		return nil
+
+	case t == _errorType, t == _signalType:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		var s string
+		if err, ok := v.Interface().(error); ok {
+			s = err.Error()
+		} else {
+			s = v.Interface().(fmt.Stringer).String()
+		}
+		b, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+
+	case t.Kind() == reflect.Struct:
+		// Write as an object, but without a leading comma.
+		var fields bytes.Buffer
+		if err := marshalFields(&fields, v); err != nil {
+			return err
+		}
+		buf.WriteByte('{')
+		buf.Write(bytes.TrimPrefix(fields.Bytes(), []byte{','}))
+		buf.WriteByte('}')
+		return nil
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct:
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := marshalValue(buf, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	default:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+}
+
+func unmarshalEvent(obj map[string]json.RawMessage) (Event, error) {
+	var name string
+	if err := json.Unmarshal(obj["event"], &name); err != nil {
+		return nil, fmt.Errorf("read event type: %w", err)
+	}
+
+	t, ok := _jsonEventTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", name)
+	}
+
+	v := reflect.New(t)
+	if err := unmarshalFields(obj, v.Elem()); err != nil {
+		return nil, fmt.Errorf("decode %v: %w", name, err)
+	}
+	return v.Interface().(Event), nil
+}
+
+// unmarshalFields fills the struct v from the keys of obj.
+func unmarshalFields(obj map[string]json.RawMessage, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		raw, ok := obj[jsonKey(f.Name)]
+		if !ok {
+			continue
+		}
+		if err := unmarshalValue(raw, v.Field(i)); err != nil {
+			return fmt.Errorf("field %v: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func unmarshalValue(raw json.RawMessage, v reflect.Value) error {
+	if bytes.Equal(raw, []byte("null")) {
+		return nil // leave as zero value
+	}
+
+	switch t := v.Type(); {
+	case t == _errorType, t == _signalType:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return err
+		}
+		if t == _errorType {
+			v.Set(reflect.ValueOf(errors.New(s)))
+		} else {
+			v.Set(reflect.ValueOf(decodedSignal(s)))
+		}
+		return nil
+
+	case t.Kind() == reflect.Struct:
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return err
+		}
+		return unmarshalFields(obj, v)
+
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Struct:
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if err := unmarshalValue(item, s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+
+	default:
+		// Durations are integers, so they decode as-is.
+		return json.Unmarshal(raw, v.Addr().Interface())
+	}
+}
+
+// decodedSignal is an os.Signal read by a JSONDecoder.
+// Only its name is known.
+type decodedSignal string
+
+var _ os.Signal = decodedSignal("")
+
+func (s decodedSignal) String() string { return string(s) }
+
+func (decodedSignal) Signal() {}
@@ -0,0 +1,198 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLogger(t *testing.T) {
+	t.Parallel()
+
+	someError := errors.New("some error")
+
+	tests := []struct {
+		name string
+		give Event
+		want string
+	}{
+		{
+			name: "OnStartExecuted",
+			give: &OnStartExecuted{
+				FunctionName: "hook.onStart",
+				CallerName:   "bytes.NewBuffer",
+				Method:       "OnStart",
+				Runtime:      3 * time.Millisecond,
+			},
+			want: `{"event":"OnStartExecuted","functionName":"hook.onStart","callerName":"bytes.NewBuffer","method":"OnStart","runtime":3000000,"err":null}`,
+		},
+		{
+			name: "Provided",
+			give: &Provided{
+				ConstructorName: "bytes.NewBuffer()",
+				StackTrace:      []string{"main.main"},
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				Err:             someError,
+			},
+			want: `{"event":"Provided","constructorName":"bytes.NewBuffer()","stackTrace":["main.main"],"moduleTrace":null,"outputTypeNames":["*bytes.Buffer"],"inputTypeNames":null,"moduleName":"","err":"some error","private":false}`,
+		},
+		{
+			name: "Stopping",
+			give: &Stopping{Signal: syscall.SIGTERM},
+			want: `{"event":"Stopping","signal":"terminated","origin":"","caller":"","exitCode":0,"reason":null,"failed":false}`,
+		},
+		{
+			name: "StartupSummary",
+			give: &StartupSummary{
+				Modules:      []ModuleSummary{{ModuleName: "", Provides: 2}},
+				SlowestHooks: []FuncRuntime{{Name: "main.start()", Runtime: time.Second}},
+			},
+			want: `{"event":"StartupSummary","modules":[{"moduleName":"","provides":2,"decorators":0,"invokes":0,"hooks":0}],` +
+				`"constructorRuntime":0,"onStartRuntime":0,"slowestConstructors":null,` +
+				`"slowestHooks":[{"name":"main.start()","moduleName":"","runtime":1000000000}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			(&JSONLogger{W: &buf}).LogEvent(tt.give)
+			assert.Equal(t, tt.want+"\n", buf.String())
+		})
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	someError := errors.New("some error")
+	events := []Event{
+		&OnStartExecuting{FunctionName: "hook.onStart", CallerName: "bytes.NewBuffer"},
+		&OnStartExecuted{FunctionName: "hook.onStart", CallerName: "bytes.NewBuffer", Method: "OnStart", Runtime: time.Millisecond},
+		&OnStopExecuting{FunctionName: "hook.onStop", CallerName: "bytes.NewBuffer"},
+		&OnStopExecuted{FunctionName: "hook.onStop", CallerName: "bytes.NewBuffer", Runtime: time.Millisecond, Err: someError},
+		&SlowHook{FunctionName: "hook.onStart", CallerName: "bytes.NewBuffer", Method: "OnStart", Threshold: time.Second, Runtime: 2 * time.Second},
+		&Supplied{TypeName: "*bytes.Buffer", StackTrace: []string{"main.main"}, ModuleTrace: []string{"main.main"}, ModuleName: "myModule"},
+		&Provided{ConstructorName: "bytes.NewBuffer()", OutputTypeNames: []string{"*bytes.Buffer"}, Private: true},
+		&Replaced{OutputTypeNames: []string{"*bytes.Buffer"}, Err: someError},
+		&Decorated{DecoratorName: "bytes.NewBuffer()", OutputTypeNames: []string{"*bytes.Buffer"}},
+		&Run{Name: "bytes.NewBuffer()", Kind: "provide", ModuleName: "myModule", Runtime: time.Millisecond},
+		&SlowConstructor{Name: "bytes.NewBuffer()", Kind: "provide", Threshold: time.Millisecond, Runtime: time.Second},
+		&Invoking{FunctionName: "main.run()", ModuleName: "myModule"},
+		&Invoked{FunctionName: "main.run()", Err: someError, Trace: "main.main\n\tmain.go:42"},
+		&ModuleBuilding{ModuleName: "myModule"},
+		&ModuleBuilt{ModuleName: "myModule", Runtime: time.Millisecond, Err: someError},
+		&ModuleHooksExecuting{ModuleName: "myModule", Method: "OnStart"},
+		&ModuleHooksExecuted{ModuleName: "myModule", Method: "OnStart", Runtime: time.Second},
+		&Stopping{Origin: "shutdowner", Caller: "main.main (main.go:42)", ExitCode: 1, Reason: someError},
+		&Stopped{Err: someError},
+		&RollingBack{StartErr: someError},
+		&RolledBack{},
+		&Started{},
+		&StartupSummary{
+			Modules:             []ModuleSummary{{ModuleName: "myModule", Provides: 1, Decorators: 2, Invokes: 3, Hooks: 4}},
+			ConstructorRuntime:  time.Millisecond,
+			OnStartRuntime:      time.Second,
+			SlowestConstructors: []FuncRuntime{{Name: "bytes.NewBuffer()", ModuleName: "myModule", Runtime: time.Millisecond}},
+		},
+		&LoggerInitialized{ConstructorName: "bytes.NewBuffer()"},
+	}
+	// Every event type must be supported.
+	require.Len(t, events, len(_jsonEventTypes))
+
+	var buf bytes.Buffer
+	logger := &JSONLogger{W: &buf}
+	for _, e := range events {
+		logger.LogEvent(e)
+	}
+	assert.Equal(t, len(events), strings.Count(buf.String(), "\n"),
+		"expected one line per event")
+
+	dec := NewJSONDecoder(&buf)
+	for _, want := range events {
+		got, err := dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	_, err := dec.Decode()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestJSONDecoder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signal", func(t *testing.T) {
+		t.Parallel()
+
+		dec := NewJSONDecoder(strings.NewReader(`{"event":"Stopping","signal":"interrupt"}`))
+		e, err := dec.Decode()
+		require.NoError(t, err)
+		require.IsType(t, &Stopping{}, e)
+		assert.Equal(t, "interrupt", e.(*Stopping).Signal.String())
+	})
+
+	t.Run("unknown keys", func(t *testing.T) {
+		t.Parallel()
+
+		dec := NewJSONDecoder(strings.NewReader(`{"event":"Started","foo":"bar"}`))
+		e, err := dec.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, &Started{}, e)
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		t.Parallel()
+
+		dec := NewJSONDecoder(strings.NewReader(`{"event":"Foo"}`))
+		_, err := dec.Decode()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown event type "Foo"`)
+	})
+
+	t.Run("bad field", func(t *testing.T) {
+		t.Parallel()
+
+		dec := NewJSONDecoder(strings.NewReader(`{"event":"Run","runtime":"1s"}`))
+		_, err := dec.Decode()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decode Run: field Runtime")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		dec := NewJSONDecoder(strings.NewReader(`{`))
+		_, err := dec.Decode()
+		require.Error(t, err)
+	})
+}
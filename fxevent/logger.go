@@ -36,3 +36,56 @@ var _ Logger = nopLogger{}
 func (nopLogger) LogEvent(Event) {}
 
 func (nopLogger) String() string { return "NopLogger" }
+
+// Tee returns a Logger that logs each event to all of the given loggers,
+// in order.
+//
+// For example, the following logs all events to Zap,
+// and also prints errors to standard error.
+//
+//	fxevent.Tee(
+//		&fxevent.ZapLogger{Logger: log},
+//		fxevent.Filter(
+//			&fxevent.ConsoleLogger{W: os.Stderr},
+//			fxevent.MinSeverity(fxevent.SeverityError),
+//		),
+//	)
+func Tee(loggers ...Logger) Logger {
+	switch len(loggers) {
+	case 0:
+		return NopLogger
+	case 1:
+		return loggers[0]
+	default:
+		return teeLogger(loggers)
+	}
+}
+
+type teeLogger []Logger
+
+var _ Logger = teeLogger(nil)
+
+func (ls teeLogger) LogEvent(event Event) {
+	for _, l := range ls {
+		l.LogEvent(event)
+	}
+}
+
+// Filter returns a Logger that logs only the events
+// for which the predicate returns true to the given logger.
+func Filter(logger Logger, predicate func(Event) bool) Logger {
+	return &filterLogger{logger: logger, predicate: predicate}
+}
+
+type filterLogger struct {
+	logger    Logger
+	predicate func(Event) bool
+}
+
+var _ Logger = (*filterLogger)(nil)
+
+func (l *filterLogger) LogEvent(event Event) {
+	if l.predicate(event) {
+		l.logger.LogEvent(event)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no loggers", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, NopLogger, Tee())
+	})
+
+	t.Run("one logger", func(t *testing.T) {
+		t.Parallel()
+
+		l := &ConsoleLogger{}
+		assert.Same(t, l, Tee(l))
+	})
+
+	t.Run("many loggers", func(t *testing.T) {
+		t.Parallel()
+
+		var a, b bytes.Buffer
+		logger := Tee(&ConsoleLogger{W: &a}, &ConsoleLogger{W: &b})
+		logger.LogEvent(&Started{})
+
+		assert.Equal(t, "[Fx] RUNNING\n", a.String())
+		assert.Equal(t, "[Fx] RUNNING\n", b.String())
+	})
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	var buff bytes.Buffer
+	logger := Filter(&ConsoleLogger{W: &buff}, MinSeverity(SeverityError))
+	logger.LogEvent(&Started{})
+	logger.LogEvent(&Started{Err: errors.New("great sadness")})
+
+	assert.Equal(t, "[Fx] ERROR\t\tFailed to start: great sadness\n", buff.String())
+}
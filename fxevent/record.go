@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// RecordedEvent is an event captured by a [Recorder].
+type RecordedEvent struct {
+	// Time is when the event was logged.
+	Time time.Time
+
+	// Event is the event that was logged.
+	Event Event
+}
+
+// Recorder is an Fx event logger that keeps every event it receives
+// in memory along with the time it was logged.
+//
+// Recorded events can be saved with [Recorder.WriteTo],
+// and fed into another logger later with [Replay].
+// This allows a failing startup to be captured in one place
+// and rendered elsewhere, without running the application again.
+//
+//	rec := new(fxevent.Recorder)
+//	app := fx.New(
+//		fx.WithLogger(func() fxevent.Logger { return rec }),
+//		...
+//	)
+//	if err := app.Err(); err != nil {
+//		f, _ := os.Create("fx-events.json")
+//		rec.WriteTo(f)
+//		f.Close()
+//	}
+//
+// The zero value is ready to use.
+type Recorder struct {
+	mu     sync.Mutex
+	events []RecordedEvent
+
+	now func() time.Time // for tests
+}
+
+var _ Logger = (*Recorder)(nil)
+
+// LogEvent records the event.
+func (r *Recorder) LogEvent(event Event) {
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, RecordedEvent{Time: now(), Event: event})
+}
+
+// Events returns a copy of the events recorded so far, in the order they
+// were logged.
+func (r *Recorder) Events() []RecordedEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedEvent(nil), r.events...)
+}
+
+// WriteTo writes the recorded events to w in the format of the [JSONLogger],
+// with an additional "time" key holding the time of the event in RFC 3339
+// format.
+func (r *Recorder) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	for _, rec := range r.Events() {
+		b, err := marshalEvent(rec.Event, rec.Time)
+		if err != nil {
+			return n, err
+		}
+		nn, err := bw.Write(append(b, '\n'))
+		n += int64(nn)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, bw.Flush()
+}
+
+// ReadRecording reads events written by [Recorder.WriteTo].
+//
+// Events written by the [JSONLogger] may be read too,
+// but they have a zero Time.
+func ReadRecording(r io.Reader) ([]RecordedEvent, error) {
+	dec := NewJSONDecoder(r)
+	var events []RecordedEvent
+	for {
+		rec, err := dec.decodeRecord()
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, rec)
+	}
+}
+
+// Replay reads events written by [Recorder.WriteTo] or the [JSONLogger]
+// and logs them to the given logger, in order.
+//
+//	f, err := os.Open("fx-events.json")
+//	...
+//	err = fxevent.Replay(f, &fxevent.ConsoleLogger{W: os.Stderr})
+//
+// Events are logged as they're read, so events before a malformed line
+// are logged before Replay returns an error.
+func Replay(r io.Reader, logger Logger) error {
+	dec := NewJSONDecoder(r)
+	for {
+		event, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logger.LogEvent(event)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	// Each event advances the clock by a second.
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := start
+	rec := &Recorder{
+		now: func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		},
+	}
+
+	events := []Event{
+		&Provided{ConstructorName: "bytes.NewBuffer()", OutputTypeNames: []string{"*bytes.Buffer"}},
+		&Invoking{FunctionName: "main.run()"},
+		&Invoked{FunctionName: "main.run()", Err: errors.New("great sadness")},
+	}
+	for _, e := range events {
+		rec.LogEvent(e)
+	}
+
+	got := rec.Events()
+	require.Len(t, got, len(events))
+	for i, e := range events {
+		assert.Same(t, e, got[i].Event)
+		assert.Equal(t, start.Add(time.Duration(i+1)*time.Second), got[i].Time)
+	}
+
+	t.Run("Events returns a copy", func(t *testing.T) {
+		rec.Events()[0].Event = nil
+		assert.NotNil(t, rec.Events()[0].Event)
+	})
+
+	var buf bytes.Buffer
+	n, err := rec.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t,
+		`{"event":"Invoking","time":"2024-01-02T03:04:07Z","functionName":"main.run()","moduleName":""}`,
+		strings.Split(buf.String(), "\n")[1])
+
+	t.Run("ReadRecording", func(t *testing.T) {
+		read, err := ReadRecording(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, got, read)
+	})
+
+	t.Run("Replay", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, Replay(bytes.NewReader(buf.Bytes()), &ConsoleLogger{W: &out}))
+		assert.Equal(t, "[Fx] PROVIDE\t*bytes.Buffer <= bytes.NewBuffer()\n"+
+			"[Fx] INVOKE\t\tmain.run()\n"+
+			"[Fx] ERROR\t\tfx.Invoke(main.run()) called from:\n"+
+			"Failed: great sadness\n", out.String())
+	})
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("JSONLogger output", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		(&JSONLogger{W: &buf}).LogEvent(&Started{})
+
+		rec := new(Recorder)
+		require.NoError(t, Replay(&buf, rec))
+		require.Len(t, rec.Events(), 1)
+		assert.Equal(t, &Started{}, rec.Events()[0].Event)
+	})
+
+	t.Run("malformed line", func(t *testing.T) {
+		t.Parallel()
+
+		rec := new(Recorder)
+		err := Replay(strings.NewReader(
+			`{"event":"Started"}`+"\n"+
+				`{"event":"Stopped","err":42}`+"\n",
+		), rec)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decode Stopped: field Err")
+
+		// Events before the malformed line are still logged.
+		require.Len(t, rec.Events(), 1)
+		assert.Equal(t, &Started{}, rec.Events()[0].Event)
+	})
+
+	t.Run("bad time", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ReadRecording(strings.NewReader(`{"event":"Started","time":"yesterday"}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "read event time")
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import "fmt"
+
+// Severity classifies how much attention an event needs.
+//
+// The ZapLogger and SlogLogger use the severity of an event
+// to decide the level it's logged at,
+// and the ConsoleLogger marks events with error severity with "ERROR".
+type Severity int
+
+const (
+	// SeverityInfo is the severity of events that report normal operation.
+	SeverityInfo Severity = iota
+
+	// SeverityWarn is the severity of events that report a potential problem,
+	// such as a function that is slower than expected.
+	SeverityWarn
+
+	// SeverityError is the severity of events that report a failure.
+	SeverityError
+)
+
+// String returns a lower-case name of the severity, e.g. "info".
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarn:
+		return "warn"
+	case SeverityError:
+		return "error"
+	default:
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+}
+
+// SeverityOf reports the severity of the given event.
+//
+// Events that carry a non-nil error, and RollingBack, are errors.
+// Stopping is an error only if the application is shutting down
+// because of an error passed to fx.ShutdownError;
+// a reason passed to fx.ShutdownReason doesn't make it one.
+// SlowHook and SlowConstructor are warnings.
+// All other events are informational.
+func SeverityOf(event Event) Severity {
+	var err error
+	switch e := event.(type) {
+	case *SlowHook, *SlowConstructor:
+		return SeverityWarn
+	case *RollingBack:
+		return SeverityError
+	case *OnStartExecuted:
+		err = e.Err
+	case *OnStopExecuted:
+		err = e.Err
+	case *Supplied:
+		err = e.Err
+	case *Provided:
+		err = e.Err
+	case *Replaced:
+		err = e.Err
+	case *Decorated:
+		err = e.Err
+	case *Run:
+		err = e.Err
+	case *Invoked:
+		err = e.Err
+	case *ModuleBuilt:
+		err = e.Err
+	case *ModuleHooksExecuted:
+		err = e.Err
+	case *Started:
+		err = e.Err
+	case *Stopping:
+		if e.Failed {
+			err = e.Reason
+		}
+	case *Stopped:
+		err = e.Err
+	case *RolledBack:
+		err = e.Err
+	case *LoggerInitialized:
+		err = e.Err
+	}
+
+	if err != nil {
+		return SeverityError
+	}
+	return SeverityInfo
+}
+
+// MinSeverity returns a predicate for [Filter] that matches events
+// with the given severity or higher.
+//
+//	fxevent.Filter(logger, fxevent.MinSeverity(fxevent.SeverityWarn))
+func MinSeverity(s Severity) func(Event) bool {
+	return func(e Event) bool {
+		return SeverityOf(e) >= s
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverityOf(t *testing.T) {
+	t.Parallel()
+
+	someError := errors.New("some error")
+	tests := []struct {
+		give Event
+		want Severity
+	}{
+		{give: &OnStartExecuting{}, want: SeverityInfo},
+		{give: &OnStartExecuted{}, want: SeverityInfo},
+		{give: &OnStartExecuted{Err: someError}, want: SeverityError},
+		{give: &OnStopExecuting{}, want: SeverityInfo},
+		{give: &OnStopExecuted{}, want: SeverityInfo},
+		{give: &OnStopExecuted{Err: someError}, want: SeverityError},
+		{give: &SlowHook{}, want: SeverityWarn},
+		{give: &Supplied{}, want: SeverityInfo},
+		{give: &Supplied{Err: someError}, want: SeverityError},
+		{give: &Provided{}, want: SeverityInfo},
+		{give: &Provided{Err: someError}, want: SeverityError},
+		{give: &Replaced{}, want: SeverityInfo},
+		{give: &Replaced{Err: someError}, want: SeverityError},
+		{give: &Decorated{}, want: SeverityInfo},
+		{give: &Decorated{Err: someError}, want: SeverityError},
+		{give: &Run{}, want: SeverityInfo},
+		{give: &Run{Err: someError}, want: SeverityError},
+		{give: &SlowConstructor{}, want: SeverityWarn},
+		{give: &Invoking{}, want: SeverityInfo},
+		{give: &Invoked{}, want: SeverityInfo},
+		{give: &Invoked{Err: someError}, want: SeverityError},
+		{give: &ModuleBuilding{}, want: SeverityInfo},
+		{give: &ModuleBuilt{}, want: SeverityInfo},
+		{give: &ModuleBuilt{Err: someError}, want: SeverityError},
+		{give: &ModuleHooksExecuting{}, want: SeverityInfo},
+		{give: &ModuleHooksExecuted{}, want: SeverityInfo},
+		{give: &ModuleHooksExecuted{Err: someError}, want: SeverityError},
+		{give: &Stopping{}, want: SeverityInfo},
+		{give: &Stopping{Reason: someError}, want: SeverityInfo},
+		{give: &Stopping{Reason: someError, Failed: true}, want: SeverityError},
+		{give: &Stopped{}, want: SeverityInfo},
+		{give: &Stopped{Err: someError}, want: SeverityError},
+		{give: &RollingBack{StartErr: someError}, want: SeverityError},
+		{give: &RolledBack{}, want: SeverityInfo},
+		{give: &RolledBack{Err: someError}, want: SeverityError},
+		{give: &Started{}, want: SeverityInfo},
+		{give: &Started{Err: someError}, want: SeverityError},
+		{give: &StartupSummary{}, want: SeverityInfo},
+		{give: &LoggerInitialized{}, want: SeverityInfo},
+		{give: &LoggerInitialized{Err: someError}, want: SeverityError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(fmt.Sprintf("%T", tt.give), func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, SeverityOf(tt.give))
+		})
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "info", SeverityInfo.String())
+	assert.Equal(t, "warn", SeverityWarn.String())
+	assert.Equal(t, "error", SeverityError.String())
+	assert.Equal(t, "Severity(42)", Severity(42).String())
+}
+
+func TestMinSeverity(t *testing.T) {
+	t.Parallel()
+
+	warn := MinSeverity(SeverityWarn)
+	assert.False(t, warn(&Started{}))
+	assert.True(t, warn(&SlowHook{}))
+	assert.True(t, warn(&Started{Err: errors.New("great sadness")}))
+}
@@ -75,66 +75,93 @@ func (l *SlogLogger) logEvent(msg string, fields ...any) {
 	l.Logger.Log(l.ctx, l.logLevel, msg, l.filter(fields)...)
 }
 
-func (l *SlogLogger) logError(msg string, fields ...any) {
-	lvl := slog.LevelError
+// log logs at the level for the given severity.
+func (l *SlogLogger) log(sev Severity, msg string, fields ...any) {
+	l.Logger.Log(l.ctx, l.level(sev), msg, l.filter(fields)...)
+}
+
+// level returns the level that events with the given severity are logged at.
+//
+// Warnings are logged at the warning level,
+// unless errors are logged at a lower level.
+func (l *SlogLogger) level(sev Severity) slog.Level {
+	errorLevel := slog.LevelError
 	if l.errorLevel != nil {
-		lvl = *l.errorLevel
+		errorLevel = *l.errorLevel
 	}
 
-	l.Logger.Log(l.ctx, lvl, msg, l.filter(fields)...)
+	switch sev {
+	case SeverityError:
+		return errorLevel
+	case SeverityWarn:
+		if errorLevel < slog.LevelWarn {
+			return errorLevel
+		}
+		return slog.LevelWarn
+	default:
+		return l.logLevel
+	}
 }
 
 // LogEvent logs the given event to the provided Zap logger.
 func (l *SlogLogger) LogEvent(event Event) {
+	sev := SeverityOf(event)
 	switch e := event.(type) {
 	case *OnStartExecuting:
-		l.logEvent("OnStart hook executing",
+		l.log(sev, "OnStart hook executing",
 			slog.String("callee", e.FunctionName),
 			slog.String("caller", e.CallerName),
 		)
 	case *OnStartExecuted:
 		if e.Err != nil {
-			l.logError("OnStart hook failed",
+			l.log(sev, "OnStart hook failed",
 				slog.String("callee", e.FunctionName),
 				slog.String("caller", e.CallerName),
 				slogErr(e.Err),
 			)
 		} else {
-			l.logEvent("OnStart hook executed",
+			l.log(sev, "OnStart hook executed",
 				slog.String("callee", e.FunctionName),
 				slog.String("caller", e.CallerName),
 				slog.String("runtime", e.Runtime.String()),
 			)
 		}
 	case *OnStopExecuting:
-		l.logEvent("OnStop hook executing",
+		l.log(sev, "OnStop hook executing",
 			slog.String("callee", e.FunctionName),
 			slog.String("caller", e.CallerName),
 		)
 	case *OnStopExecuted:
 		if e.Err != nil {
-			l.logError("OnStop hook failed",
+			l.log(sev, "OnStop hook failed",
 				slog.String("callee", e.FunctionName),
 				slog.String("caller", e.CallerName),
 				slogErr(e.Err),
 			)
 		} else {
-			l.logEvent("OnStop hook executed",
+			l.log(sev, "OnStop hook executed",
 				slog.String("callee", e.FunctionName),
 				slog.String("caller", e.CallerName),
 				slog.String("runtime", e.Runtime.String()),
 			)
 		}
+	case *SlowHook:
+		l.log(sev, e.Method+" hook is slow",
+			slog.String("callee", e.FunctionName),
+			slog.String("caller", e.CallerName),
+			slog.String("runtime", e.Runtime.String()),
+			slog.String("threshold", e.Threshold.String()),
+		)
 	case *Supplied:
 		if e.Err != nil {
-			l.logError("error encountered while applying options",
+			l.log(sev, "error encountered while applying options",
 				slog.String("type", e.TypeName),
 				slogStrings("moduletrace", e.ModuleTrace),
 				slogStrings("stacktrace", e.StackTrace),
 				slogMaybeModuleField(e.ModuleName),
 				slogErr(e.Err))
 		} else {
-			l.logEvent("supplied",
+			l.log(sev, "supplied",
 				slog.String("type", e.TypeName),
 				slogStrings("stacktrace", e.StackTrace),
 				slogStrings("moduletrace", e.ModuleTrace),
@@ -149,11 +176,12 @@ func (l *SlogLogger) LogEvent(event Event) {
 				slogStrings("moduletrace", e.ModuleTrace),
 				slogMaybeModuleField(e.ModuleName),
 				slog.String("type", rtype),
+				slogMaybeStrings("inputs", e.InputTypeNames),
 				slogMaybeBool("private", e.Private),
 			)
 		}
 		if e.Err != nil {
-			l.logError("error encountered while applying options",
+			l.log(sev, "error encountered while applying options",
 				slogMaybeModuleField(e.ModuleName),
 				slogStrings("stacktrace", e.StackTrace),
 				slogStrings("moduletrace", e.ModuleTrace),
@@ -169,7 +197,7 @@ func (l *SlogLogger) LogEvent(event Event) {
 			)
 		}
 		if e.Err != nil {
-			l.logError("error encountered while replacing",
+			l.log(sev, "error encountered while replacing",
 				slogStrings("stacktrace", e.StackTrace),
 				slogStrings("moduletrace", e.ModuleTrace),
 				slogMaybeModuleField(e.ModuleName),
@@ -183,10 +211,11 @@ func (l *SlogLogger) LogEvent(event Event) {
 				slogStrings("moduletrace", e.ModuleTrace),
 				slogMaybeModuleField(e.ModuleName),
 				slog.String("type", rtype),
+				slogMaybeStrings("inputs", e.InputTypeNames),
 			)
 		}
 		if e.Err != nil {
-			l.logError("error encountered while applying options",
+			l.log(sev, "error encountered while applying options",
 				slogStrings("stacktrace", e.StackTrace),
 				slogStrings("moduletrace", e.ModuleTrace),
 				slogMaybeModuleField(e.ModuleName),
@@ -194,59 +223,125 @@ func (l *SlogLogger) LogEvent(event Event) {
 		}
 	case *Run:
 		if e.Err != nil {
-			l.logError("error returned",
+			l.log(sev, "error returned",
 				slog.String("name", e.Name),
 				slog.String("kind", e.Kind),
 				slogMaybeModuleField(e.ModuleName),
 				slogErr(e.Err),
 			)
 		} else {
-			l.logEvent("run",
+			l.log(sev, "run",
 				slog.String("name", e.Name),
 				slog.String("kind", e.Kind),
 				slog.String("runtime", e.Runtime.String()),
 				slogMaybeModuleField(e.ModuleName),
 			)
 		}
+	case *SlowConstructor:
+		l.log(sev, "slow function",
+			slog.String("name", e.Name),
+			slog.String("kind", e.Kind),
+			slogMaybeModuleField(e.ModuleName),
+			slog.String("runtime", e.Runtime.String()),
+			slog.String("threshold", e.Threshold.String()),
+		)
 	case *Invoking:
 		// Do not log stack as it will make logs hard to read.
-		l.logEvent("invoking",
+		l.log(sev, "invoking",
 			slog.String("function", e.FunctionName),
 			slogMaybeModuleField(e.ModuleName),
 		)
 	case *Invoked:
 		if e.Err != nil {
-			l.logError("invoke failed",
+			l.log(sev, "invoke failed",
 				slogErr(e.Err),
 				slog.String("stack", e.Trace),
 				slog.String("function", e.FunctionName),
 				slogMaybeModuleField(e.ModuleName),
 			)
 		}
+	case *ModuleBuilding:
+		l.log(sev, "building module", slogMaybeModuleField(e.ModuleName))
+	case *ModuleBuilt:
+		if e.Err != nil {
+			l.log(sev, "module build failed",
+				slogMaybeModuleField(e.ModuleName),
+				slog.String("runtime", e.Runtime.String()),
+				slogErr(e.Err),
+			)
+		} else {
+			l.log(sev, "built module",
+				slogMaybeModuleField(e.ModuleName),
+				slog.String("runtime", e.Runtime.String()),
+			)
+		}
+	case *ModuleHooksExecuting:
+		l.log(sev, e.Method+" hooks of module executing", slogMaybeModuleField(e.ModuleName))
+	case *ModuleHooksExecuted:
+		if e.Err != nil {
+			l.log(sev, e.Method+" hooks of module failed",
+				slogMaybeModuleField(e.ModuleName),
+				slog.String("runtime", e.Runtime.String()),
+				slogErr(e.Err),
+			)
+		} else {
+			l.log(sev, e.Method+" hooks of module executed",
+				slogMaybeModuleField(e.ModuleName),
+				slog.String("runtime", e.Runtime.String()),
+			)
+		}
 	case *Stopping:
-		l.logEvent("received signal",
-			slog.String("signal", strings.ToUpper(e.Signal.String())))
+		if e.Origin == "context" {
+			l.log(sev, "context done")
+			break
+		}
+		if e.Origin != "shutdowner" {
+			l.log(sev, "received signal",
+				slog.String("signal", strings.ToUpper(e.Signal.String())))
+			break
+		}
+		fields := []any{
+			slog.String("caller", e.Caller),
+			slog.Int("exitCode", e.ExitCode),
+		}
+		if e.Reason != nil {
+			fields = append(fields, slogErr(e.Reason))
+		}
+		l.log(sev, "shutdown requested", fields...)
 	case *Stopped:
 		if e.Err != nil {
-			l.logError("stop failed", slogErr(e.Err))
+			l.log(sev, "stop failed", slogErr(e.Err))
 		}
 	case *RollingBack:
-		l.logError("start failed, rolling back", slogErr(e.StartErr))
+		l.log(sev, "start failed, rolling back", slogErr(e.StartErr))
 	case *RolledBack:
 		if e.Err != nil {
-			l.logError("rollback failed", slogErr(e.Err))
+			l.log(sev, "rollback failed", slogErr(e.Err))
 		}
 	case *Started:
 		if e.Err != nil {
-			l.logError("start failed", slogErr(e.Err))
+			l.log(sev, "start failed", slogErr(e.Err))
 		} else {
-			l.logEvent("started")
+			l.log(sev, "started")
 		}
+	case *StartupSummary:
+		total := e.total()
+		l.log(sev, "startup summary",
+			slog.Int("modules", len(e.Modules)),
+			slog.Int("provides", total.Provides),
+			slog.Int("decorators", total.Decorators),
+			slog.Int("invokes", total.Invokes),
+			slog.Int("hooks", total.Hooks),
+			slog.String("constructorRuntime", e.ConstructorRuntime.String()),
+			slog.String("onStartRuntime", e.OnStartRuntime.String()),
+			slogStrings("slowestConstructors", funcRuntimeStrings(e.SlowestConstructors)),
+			slogStrings("slowestHooks", funcRuntimeStrings(e.SlowestHooks)),
+		)
 	case *LoggerInitialized:
 		if e.Err != nil {
-			l.logError("custom logger initialization failed", slogErr(e.Err))
+			l.log(sev, "custom logger initialization failed", slogErr(e.Err))
 		} else {
-			l.logEvent("initialized custom fxevent.Logger", slog.String("function", e.ConstructorName))
+			l.log(sev, "initialized custom fxevent.Logger", slog.String("function", e.ConstructorName))
 		}
 	}
 }
@@ -267,6 +362,13 @@ func slogMaybeBool(name string, b bool) slog.Attr {
 	return slog.Bool(name, true)
 }
 
+func slogMaybeStrings(name string, strs []string) slog.Attr {
+	if len(strs) == 0 {
+		return slog.Any(name, slogFieldSkip{})
+	}
+	return slogStrings(name, strs)
+}
+
 func slogErr(err error) slog.Attr {
 	return slog.String("error", err.Error())
 }
@@ -0,0 +1,275 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// TraceLogger is an Fx event logger that writes a timeline of the
+// application's startup and shutdown in the Chrome trace event format.
+// The output may be loaded into chrome://tracing or https://ui.perfetto.dev.
+//
+//	f, err := os.Create("fx-trace.json")
+//	...
+//	fx.WithLogger(func() fxevent.Logger {
+//		return &fxevent.TraceLogger{W: f}
+//	})
+//
+// Constructors, decorators, and invokes are recorded as spans on a track
+// for the module they belong to. Since constructors run when their results
+// are first requested, they appear during the invoke that requested them.
+// Dig builds the parameters of a constructor before calling it, so the
+// constructors that an invoke needs appear one after another, each after
+// the constructors it depends on, rather than nested inside each other.
+// Lifecycle hooks are recorded on a separate track,
+// and other events are recorded as instants.
+//
+// Events are written as they are received, so the trace remains usable
+// even if the application never stops. The trace is terminated after the
+// first [Stopped] event, and later events are dropped: if the application
+// is started and stopped again, only the first cycle is recorded.
+type TraceLogger struct {
+	W io.Writer
+
+	mu      sync.Mutex
+	closed  bool
+	written int // number of events written
+	epoch   time.Time
+	tracks  map[string]int   // module name => tid
+	now     func() time.Time // for tests
+}
+
+var _ Logger = (*TraceLogger)(nil)
+
+const (
+	_tracePID       = 1
+	_traceHooksTID  = 1
+	_traceModuleTID = 2 // first tid used for modules
+)
+
+// traceEvent is a single event in the Chrome trace event format.
+//
+// See https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU.
+type traceEvent struct {
+	Name  string         `json:"name"`
+	Cat   string         `json:"cat,omitempty"`
+	Phase string         `json:"ph"`
+	TS    float64        `json:"ts"` // microseconds
+	Dur   *float64       `json:"dur,omitempty"`
+	PID   int            `json:"pid"`
+	TID   int            `json:"tid"`
+	Scope string         `json:"s,omitempty"`
+	Args  map[string]any `json:"args,omitempty"`
+}
+
+func traceMicros(d time.Duration) float64 {
+	return float64(d.Nanoseconds()) / 1e3
+}
+
+// LogEvent writes the given event to the trace.
+func (l *TraceLogger) LogEvent(event Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+
+	now := l.clock()
+	if l.written == 0 {
+		l.epoch = now
+		io.WriteString(l.W, "[\n")
+		l.write(traceEvent{
+			Name:  "process_name",
+			Phase: "M",
+			PID:   _tracePID,
+			Args:  map[string]any{"name": "fx"},
+		})
+		l.write(traceEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			PID:   _tracePID,
+			TID:   _traceHooksTID,
+			Args:  map[string]any{"name": "lifecycle"},
+		})
+	}
+	ts := now.Sub(l.epoch)
+
+	switch e := event.(type) {
+	case *Run:
+		l.span(e.Kind, e.Name, l.track(e.ModuleName), ts, e.Runtime, e.Err)
+	case *Invoking:
+		l.write(traceEvent{
+			Name:  e.FunctionName,
+			Cat:   "invoke",
+			Phase: "B",
+			TS:    traceMicros(ts),
+			PID:   _tracePID,
+			TID:   l.track(e.ModuleName),
+		})
+	case *Invoked:
+		l.write(traceEvent{
+			Name:  e.FunctionName,
+			Cat:   "invoke",
+			Phase: "E",
+			TS:    traceMicros(ts),
+			PID:   _tracePID,
+			TID:   l.track(e.ModuleName),
+			Args:  traceErrorArgs(e.Err),
+		})
+	case *ModuleBuilding:
+		l.edge("B", "module", e.ModuleName, l.track(e.ModuleName), ts, nil)
+	case *ModuleBuilt:
+		l.edge("E", "module", e.ModuleName, l.track(e.ModuleName), ts, e.Err)
+	case *ModuleHooksExecuting:
+		l.edge("B", e.Method, e.Method+" hooks", l.track(e.ModuleName), ts, nil)
+	case *ModuleHooksExecuted:
+		l.edge("E", e.Method, e.Method+" hooks", l.track(e.ModuleName), ts, e.Err)
+	case *Provided, *Supplied, *Decorated, *Replaced:
+		// Constructors are recorded when they are run.
+	case *OnStartExecuting, *OnStopExecuting:
+		// Hooks are recorded once they have finished running.
+	case *StartupSummary:
+		// Derived from the events already in the trace.
+	case *OnStartExecuted:
+		l.span("OnStart", e.FunctionName, _traceHooksTID, ts, e.Runtime, e.Err)
+	case *OnStopExecuted:
+		l.span("OnStop", e.FunctionName, _traceHooksTID, ts, e.Runtime, e.Err)
+	case *SlowHook, *SlowConstructor:
+		// Slow functions are visible in the spans.
+	case *LoggerInitialized:
+		l.instant("LoggerInitialized", ts, e.Err)
+	case *Started:
+		l.instant("Started", ts, e.Err)
+	case *Stopping:
+		l.instant("Stopping", ts, e.Reason)
+	case *RollingBack:
+		l.instant("RollingBack", ts, e.StartErr)
+	case *RolledBack:
+		l.instant("RolledBack", ts, e.Err)
+	case *Stopped:
+		l.instant("Stopped", ts, e.Err)
+		io.WriteString(l.W, "\n]\n")
+		l.closed = true
+	}
+}
+
+func (l *TraceLogger) clock() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
+
+// track returns the thread ID used for events in the given module,
+// naming the thread if this is the first event in the module.
+func (l *TraceLogger) track(moduleName string) int {
+	if tid, ok := l.tracks[moduleName]; ok {
+		return tid
+	}
+	if l.tracks == nil {
+		l.tracks = make(map[string]int)
+	}
+
+	tid := _traceModuleTID + len(l.tracks)
+	l.tracks[moduleName] = tid
+
+	name := moduleName
+	if name == "" {
+		name = "(root)"
+	}
+	l.write(traceEvent{
+		Name:  "thread_name",
+		Phase: "M",
+		PID:   _tracePID,
+		TID:   tid,
+		Args:  map[string]any{"name": name},
+	})
+	return tid
+}
+
+// span writes a complete event for a function that finished at end
+// after running for runtime.
+func (l *TraceLogger) span(cat, name string, tid int, end, runtime time.Duration, err error) {
+	dur := traceMicros(runtime)
+	l.write(traceEvent{
+		Name:  name,
+		Cat:   cat,
+		Phase: "X",
+		TS:    traceMicros(end - runtime),
+		Dur:   &dur,
+		PID:   _tracePID,
+		TID:   tid,
+		Args:  traceErrorArgs(err),
+	})
+}
+
+// edge writes the beginning ("B") or end ("E") of a duration event.
+func (l *TraceLogger) edge(phase, cat, name string, tid int, ts time.Duration, err error) {
+	l.write(traceEvent{
+		Name:  name,
+		Cat:   cat,
+		Phase: phase,
+		TS:    traceMicros(ts),
+		PID:   _tracePID,
+		TID:   tid,
+		Args:  traceErrorArgs(err),
+	})
+}
+
+// instant writes a process-wide instant event.
+func (l *TraceLogger) instant(name string, ts time.Duration, err error) {
+	l.write(traceEvent{
+		Name:  name,
+		Cat:   "fx",
+		Phase: "i",
+		TS:    traceMicros(ts),
+		PID:   _tracePID,
+		TID:   _traceHooksTID,
+		Scope: "p",
+		Args:  traceErrorArgs(err),
+	})
+}
+
+func (l *TraceLogger) write(e traceEvent) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		// Only possible if an argument can't be marshaled,
+		// and we only use strings.
+		return
+	}
+	if l.written > 0 {
+		io.WriteString(l.W, ",\n")
+	}
+	l.W.Write(b)
+	l.written++
+}
+
+func traceErrorArgs(err error) map[string]any {
+	if err == nil {
+		return nil
+	}
+	return map[string]any{"error": err.Error()}
+}
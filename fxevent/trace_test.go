@@ -0,0 +1,147 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxevent
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceLogger(t *testing.T) {
+	t.Parallel()
+
+	// Each event advances the clock by 10ms.
+	var now time.Time
+	var buf bytes.Buffer
+	logger := &TraceLogger{
+		W: &buf,
+		now: func() time.Time {
+			now = now.Add(10 * time.Millisecond)
+			return now
+		},
+	}
+
+	for _, e := range []Event{
+		&Provided{ConstructorName: "bytes.NewBuffer()"}, // 10ms
+		&Invoking{FunctionName: "main.run()"},           // 20ms
+		&Run{ // 30ms
+			Name:       "bytes.NewBuffer()",
+			Kind:       "provide",
+			ModuleName: "buffers",
+			Runtime:    5 * time.Millisecond,
+		},
+		&Invoked{FunctionName: "main.run()"}, // 40ms
+		&OnStartExecuted{ // 50ms
+			FunctionName: "main.start()",
+			Runtime:      time.Millisecond,
+			Err:          errors.New("great sadness"),
+		},
+		&Started{},           // 60ms
+		&Stopped{},           // 70ms
+		&Invoking{},          // ignored after Stopped
+		&Provided{},          // ignored
+		&LoggerInitialized{}, // ignored
+		&Started{},           // ignored: only the first cycle is recorded
+		&Stopped{},           // ignored
+	} {
+		logger.LogEvent(e)
+	}
+
+	var events []traceEvent
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &events), "invalid trace:\n%s", buf.String())
+
+	dur := func(d float64) *float64 { return &d }
+	assert.Equal(t, []traceEvent{
+		{Name: "process_name", Phase: "M", PID: 1, Args: map[string]any{"name": "fx"}},
+		{Name: "thread_name", Phase: "M", PID: 1, TID: 1, Args: map[string]any{"name": "lifecycle"}},
+		{Name: "thread_name", Phase: "M", PID: 1, TID: 2, Args: map[string]any{"name": "(root)"}},
+		{Name: "main.run()", Cat: "invoke", Phase: "B", TS: 10_000, PID: 1, TID: 2},
+		{Name: "thread_name", Phase: "M", PID: 1, TID: 3, Args: map[string]any{"name": "buffers"}},
+		{Name: "bytes.NewBuffer()", Cat: "provide", Phase: "X", TS: 15_000, Dur: dur(5_000), PID: 1, TID: 3},
+		{Name: "main.run()", Cat: "invoke", Phase: "E", TS: 30_000, PID: 1, TID: 2},
+		{
+			Name: "main.start()", Cat: "OnStart", Phase: "X", TS: 39_000, Dur: dur(1_000), PID: 1, TID: 1,
+			Args: map[string]any{"error": "great sadness"},
+		},
+		{Name: "Started", Cat: "fx", Phase: "i", TS: 50_000, PID: 1, TID: 1, Scope: "p"},
+		{Name: "Stopped", Cat: "fx", Phase: "i", TS: 60_000, PID: 1, TID: 1, Scope: "p"},
+	}, events)
+}
+
+func TestTraceLoggerUnterminated(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := &TraceLogger{W: &buf}
+	logger.LogEvent(&Run{Name: "foo()", Kind: "provide"})
+	logger.LogEvent(&Started{})
+
+	// Trace viewers accept traces without the closing bracket,
+	// so the trace is usable before the application stops.
+	var events []traceEvent
+	require.NoError(t, json.Unmarshal(append(buf.Bytes(), ']'), &events), "invalid trace:\n%s", buf.String())
+	assert.Len(t, events, 5)
+}
+
+func TestTraceLoggerModules(t *testing.T) {
+	t.Parallel()
+
+	// Each event advances the clock by 10ms.
+	var now time.Time
+	var buf bytes.Buffer
+	logger := &TraceLogger{
+		W: &buf,
+		now: func() time.Time {
+			now = now.Add(10 * time.Millisecond)
+			return now
+		},
+	}
+
+	for _, e := range []Event{
+		&ModuleBuilding{ModuleName: "buffers"},                                                           // 10ms
+		&ModuleBuilt{ModuleName: "buffers", Runtime: 10 * time.Millisecond},                              // 20ms
+		&ModuleHooksExecuting{ModuleName: "buffers", Method: "OnStart"},                                  // 30ms
+		&ModuleHooksExecuted{ModuleName: "buffers", Method: "OnStart", Err: errors.New("great sadness")}, // 40ms
+	} {
+		logger.LogEvent(e)
+	}
+
+	var events []traceEvent
+	require.NoError(t, json.Unmarshal(append(buf.Bytes(), ']'), &events), "invalid trace:\n%s", buf.String())
+	assert.Equal(t, []traceEvent{
+		{Name: "process_name", Phase: "M", PID: 1, Args: map[string]any{"name": "fx"}},
+		{Name: "thread_name", Phase: "M", PID: 1, TID: 1, Args: map[string]any{"name": "lifecycle"}},
+		{Name: "thread_name", Phase: "M", PID: 1, TID: 2, Args: map[string]any{"name": "buffers"}},
+		{Name: "buffers", Cat: "module", Phase: "B", PID: 1, TID: 2},
+		{Name: "buffers", Cat: "module", Phase: "E", TS: 10_000, PID: 1, TID: 2},
+		{Name: "OnStart hooks", Cat: "OnStart", Phase: "B", TS: 20_000, PID: 1, TID: 2},
+		{
+			Name: "OnStart hooks", Cat: "OnStart", Phase: "E", TS: 30_000, PID: 1, TID: 2,
+			Args: map[string]any{"error": "great sadness"},
+		},
+	}, events)
+}
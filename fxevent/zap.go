@@ -51,65 +51,93 @@ func (l *ZapLogger) logEvent(msg string, fields ...zap.Field) {
 	l.Logger.Log(l.logLevel, msg, fields...)
 }
 
-func (l *ZapLogger) logError(msg string, fields ...zap.Field) {
-	lvl := zapcore.ErrorLevel
+// log logs at the level for the given severity.
+func (l *ZapLogger) log(sev Severity, msg string, fields ...zap.Field) {
+	l.Logger.Log(l.level(sev), msg, fields...)
+}
+
+// level returns the level that events with the given severity are logged at.
+//
+// Warnings are logged at the warning level,
+// unless errors are logged at a lower level.
+func (l *ZapLogger) level(sev Severity) zapcore.Level {
+	errorLevel := zapcore.ErrorLevel
 	if l.errorLevel != nil {
-		lvl = *l.errorLevel
+		errorLevel = *l.errorLevel
+	}
+
+	switch sev {
+	case SeverityError:
+		return errorLevel
+	case SeverityWarn:
+		if errorLevel < zapcore.WarnLevel {
+			return errorLevel
+		}
+		return zapcore.WarnLevel
+	default:
+		return l.logLevel
 	}
-	l.Logger.Log(lvl, msg, fields...)
 }
 
 // LogEvent logs the given event to the provided Zap logger.
 func (l *ZapLogger) LogEvent(event Event) {
+	sev := SeverityOf(event)
 	switch e := event.(type) {
 	case *OnStartExecuting:
-		l.logEvent("OnStart hook executing",
+		l.log(sev, "OnStart hook executing",
 			zap.String("callee", e.FunctionName),
 			zap.String("caller", e.CallerName),
 		)
 	case *OnStartExecuted:
 		if e.Err != nil {
-			l.logError("OnStart hook failed",
+			l.log(sev, "OnStart hook failed",
 				zap.String("callee", e.FunctionName),
 				zap.String("caller", e.CallerName),
 				zap.Error(e.Err),
 			)
 		} else {
-			l.logEvent("OnStart hook executed",
+			l.log(sev, "OnStart hook executed",
 				zap.String("callee", e.FunctionName),
 				zap.String("caller", e.CallerName),
 				zap.String("runtime", e.Runtime.String()),
 			)
 		}
 	case *OnStopExecuting:
-		l.logEvent("OnStop hook executing",
+		l.log(sev, "OnStop hook executing",
 			zap.String("callee", e.FunctionName),
 			zap.String("caller", e.CallerName),
 		)
 	case *OnStopExecuted:
 		if e.Err != nil {
-			l.logError("OnStop hook failed",
+			l.log(sev, "OnStop hook failed",
 				zap.String("callee", e.FunctionName),
 				zap.String("caller", e.CallerName),
 				zap.Error(e.Err),
 			)
 		} else {
-			l.logEvent("OnStop hook executed",
+			l.log(sev, "OnStop hook executed",
 				zap.String("callee", e.FunctionName),
 				zap.String("caller", e.CallerName),
 				zap.String("runtime", e.Runtime.String()),
 			)
 		}
+	case *SlowHook:
+		l.log(sev, e.Method+" hook is slow",
+			zap.String("callee", e.FunctionName),
+			zap.String("caller", e.CallerName),
+			zap.String("runtime", e.Runtime.String()),
+			zap.String("threshold", e.Threshold.String()),
+		)
 	case *Supplied:
 		if e.Err != nil {
-			l.logError("error encountered while applying options",
+			l.log(sev, "error encountered while applying options",
 				zap.String("type", e.TypeName),
 				zap.Strings("stacktrace", e.StackTrace),
 				zap.Strings("moduletrace", e.ModuleTrace),
 				moduleField(e.ModuleName),
 				zap.Error(e.Err))
 		} else {
-			l.logEvent("supplied",
+			l.log(sev, "supplied",
 				zap.String("type", e.TypeName),
 				zap.Strings("stacktrace", e.StackTrace),
 				zap.Strings("moduletrace", e.ModuleTrace),
@@ -124,11 +152,12 @@ func (l *ZapLogger) LogEvent(event Event) {
 				zap.Strings("moduletrace", e.ModuleTrace),
 				moduleField(e.ModuleName),
 				zap.String("type", rtype),
+				maybeStrings("inputs", e.InputTypeNames),
 				maybeBool("private", e.Private),
 			)
 		}
 		if e.Err != nil {
-			l.logError("error encountered while applying options",
+			l.log(sev, "error encountered while applying options",
 				moduleField(e.ModuleName),
 				zap.Strings("stacktrace", e.StackTrace),
 				zap.Strings("moduletrace", e.ModuleTrace),
@@ -144,7 +173,7 @@ func (l *ZapLogger) LogEvent(event Event) {
 			)
 		}
 		if e.Err != nil {
-			l.logError("error encountered while replacing",
+			l.log(sev, "error encountered while replacing",
 				zap.Strings("stacktrace", e.StackTrace),
 				zap.Strings("moduletrace", e.ModuleTrace),
 				moduleField(e.ModuleName),
@@ -158,10 +187,11 @@ func (l *ZapLogger) LogEvent(event Event) {
 				zap.Strings("moduletrace", e.ModuleTrace),
 				moduleField(e.ModuleName),
 				zap.String("type", rtype),
+				maybeStrings("inputs", e.InputTypeNames),
 			)
 		}
 		if e.Err != nil {
-			l.logError("error encountered while applying options",
+			l.log(sev, "error encountered while applying options",
 				zap.Strings("stacktrace", e.StackTrace),
 				zap.Strings("moduletrace", e.ModuleTrace),
 				moduleField(e.ModuleName),
@@ -169,59 +199,125 @@ func (l *ZapLogger) LogEvent(event Event) {
 		}
 	case *Run:
 		if e.Err != nil {
-			l.logError("error returned",
+			l.log(sev, "error returned",
 				zap.String("name", e.Name),
 				zap.String("kind", e.Kind),
 				moduleField(e.ModuleName),
 				zap.Error(e.Err),
 			)
 		} else {
-			l.logEvent("run",
+			l.log(sev, "run",
 				zap.String("name", e.Name),
 				zap.String("kind", e.Kind),
 				zap.String("runtime", e.Runtime.String()),
 				moduleField(e.ModuleName),
 			)
 		}
+	case *SlowConstructor:
+		l.log(sev, "slow function",
+			zap.String("name", e.Name),
+			zap.String("kind", e.Kind),
+			moduleField(e.ModuleName),
+			zap.String("runtime", e.Runtime.String()),
+			zap.String("threshold", e.Threshold.String()),
+		)
 	case *Invoking:
 		// Do not log stack as it will make logs hard to read.
-		l.logEvent("invoking",
+		l.log(sev, "invoking",
 			zap.String("function", e.FunctionName),
 			moduleField(e.ModuleName),
 		)
 	case *Invoked:
 		if e.Err != nil {
-			l.logError("invoke failed",
+			l.log(sev, "invoke failed",
 				zap.Error(e.Err),
 				zap.String("stack", e.Trace),
 				zap.String("function", e.FunctionName),
 				moduleField(e.ModuleName),
 			)
 		}
+	case *ModuleBuilding:
+		l.log(sev, "building module", moduleField(e.ModuleName))
+	case *ModuleBuilt:
+		if e.Err != nil {
+			l.log(sev, "module build failed",
+				moduleField(e.ModuleName),
+				zap.String("runtime", e.Runtime.String()),
+				zap.Error(e.Err),
+			)
+		} else {
+			l.log(sev, "built module",
+				moduleField(e.ModuleName),
+				zap.String("runtime", e.Runtime.String()),
+			)
+		}
+	case *ModuleHooksExecuting:
+		l.log(sev, e.Method+" hooks of module executing", moduleField(e.ModuleName))
+	case *ModuleHooksExecuted:
+		if e.Err != nil {
+			l.log(sev, e.Method+" hooks of module failed",
+				moduleField(e.ModuleName),
+				zap.String("runtime", e.Runtime.String()),
+				zap.Error(e.Err),
+			)
+		} else {
+			l.log(sev, e.Method+" hooks of module executed",
+				moduleField(e.ModuleName),
+				zap.String("runtime", e.Runtime.String()),
+			)
+		}
 	case *Stopping:
-		l.logEvent("received signal",
-			zap.String("signal", strings.ToUpper(e.Signal.String())))
+		if e.Origin == "context" {
+			l.log(sev, "context done")
+			break
+		}
+		if e.Origin != "shutdowner" {
+			l.log(sev, "received signal",
+				zap.String("signal", strings.ToUpper(e.Signal.String())))
+			break
+		}
+		fields := []zap.Field{
+			zap.String("caller", e.Caller),
+			zap.Int("exitCode", e.ExitCode),
+		}
+		if e.Reason != nil {
+			fields = append(fields, zap.Error(e.Reason))
+		}
+		l.log(sev, "shutdown requested", fields...)
 	case *Stopped:
 		if e.Err != nil {
-			l.logError("stop failed", zap.Error(e.Err))
+			l.log(sev, "stop failed", zap.Error(e.Err))
 		}
 	case *RollingBack:
-		l.logError("start failed, rolling back", zap.Error(e.StartErr))
+		l.log(sev, "start failed, rolling back", zap.Error(e.StartErr))
 	case *RolledBack:
 		if e.Err != nil {
-			l.logError("rollback failed", zap.Error(e.Err))
+			l.log(sev, "rollback failed", zap.Error(e.Err))
 		}
 	case *Started:
 		if e.Err != nil {
-			l.logError("start failed", zap.Error(e.Err))
+			l.log(sev, "start failed", zap.Error(e.Err))
 		} else {
-			l.logEvent("started")
+			l.log(sev, "started")
 		}
+	case *StartupSummary:
+		total := e.total()
+		l.log(sev, "startup summary",
+			zap.Int("modules", len(e.Modules)),
+			zap.Int("provides", total.Provides),
+			zap.Int("decorators", total.Decorators),
+			zap.Int("invokes", total.Invokes),
+			zap.Int("hooks", total.Hooks),
+			zap.String("constructorRuntime", e.ConstructorRuntime.String()),
+			zap.String("onStartRuntime", e.OnStartRuntime.String()),
+			zap.Strings("slowestConstructors", funcRuntimeStrings(e.SlowestConstructors)),
+			zap.Strings("slowestHooks", funcRuntimeStrings(e.SlowestHooks)),
+		)
 	case *LoggerInitialized:
 		if e.Err != nil {
-			l.logError("custom logger initialization failed", zap.Error(e.Err))
+			l.log(sev, "custom logger initialization failed", zap.Error(e.Err))
 		} else {
-			l.logEvent("initialized custom fxevent.Logger", zap.String("function", e.ConstructorName))
+			l.log(sev, "initialized custom fxevent.Logger", zap.String("function", e.ConstructorName))
 		}
 	}
 }
@@ -233,6 +329,13 @@ func moduleField(name string) zap.Field {
 	return zap.String("module", name)
 }
 
+func maybeStrings(name string, strs []string) zap.Field {
+	if len(strs) == 0 {
+		return zap.Skip()
+	}
+	return zap.Strings(name, strs)
+}
+
 func maybeBool(name string, b bool) zap.Field {
 	if b {
 		return zap.Bool(name, true)
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -175,6 +176,24 @@ func TestZapLogger(t *testing.T) {
 				"module":      "myModule",
 			},
 		},
+		{
+			name: "ProvideWithInputs",
+			give: &Provided{
+				ConstructorName: "bytes.NewBuffer()",
+				StackTrace:      []string{"main.main", "runtime.main"},
+				ModuleTrace:     []string{"main.main"},
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				InputTypeNames:  []string{"[]uint8", `string[optional, name = "prefix"]`},
+			},
+			wantMessage: "provided",
+			wantFields: map[string]interface{}{
+				"constructor": "bytes.NewBuffer()",
+				"stacktrace":  []interface{}{"main.main", "runtime.main"},
+				"moduletrace": []interface{}{"main.main"},
+				"type":        "*bytes.Buffer",
+				"inputs":      []interface{}{"[]uint8", `string[optional, name = "prefix"]`},
+			},
+		},
 		{
 			name: "PrivateProvide",
 			give: &Provided{
@@ -258,6 +277,24 @@ func TestZapLogger(t *testing.T) {
 				"module":      "myModule",
 			},
 		},
+		{
+			name: "DecorateWithInputs",
+			give: &Decorated{
+				DecoratorName:   "bytes.NewBuffer()",
+				StackTrace:      []string{"main.main", "runtime.main"},
+				ModuleTrace:     []string{"main.main"},
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				InputTypeNames:  []string{"*bytes.Buffer"},
+			},
+			wantMessage: "decorated",
+			wantFields: map[string]interface{}{
+				"decorator":   "bytes.NewBuffer()",
+				"stacktrace":  []interface{}{"main.main", "runtime.main"},
+				"moduletrace": []interface{}{"main.main"},
+				"type":        "*bytes.Buffer",
+				"inputs":      []interface{}{"*bytes.Buffer"},
+			},
+		},
 		{
 			name: "Decorate/Error",
 			give: &Decorated{
@@ -331,6 +368,60 @@ func TestZapLogger(t *testing.T) {
 				"function": "bytes.NewBuffer()",
 			},
 		},
+		{
+			name:        "ModuleBuilding",
+			give:        &ModuleBuilding{ModuleName: "myModule"},
+			wantMessage: "building module",
+			wantFields: map[string]interface{}{
+				"module": "myModule",
+			},
+		},
+		{
+			name:        "ModuleBuilt",
+			give:        &ModuleBuilt{ModuleName: "myModule", Runtime: time.Millisecond},
+			wantMessage: "built module",
+			wantFields: map[string]interface{}{
+				"module":  "myModule",
+				"runtime": "1ms",
+			},
+		},
+		{
+			name:        "ModuleBuilt/Error",
+			give:        &ModuleBuilt{ModuleName: "myModule", Runtime: time.Millisecond, Err: someError},
+			wantMessage: "module build failed",
+			wantFields: map[string]interface{}{
+				"module":  "myModule",
+				"runtime": "1ms",
+				"error":   "some error",
+			},
+		},
+		{
+			name:        "ModuleHooksExecuting",
+			give:        &ModuleHooksExecuting{ModuleName: "myModule", Method: "OnStart"},
+			wantMessage: "OnStart hooks of module executing",
+			wantFields: map[string]interface{}{
+				"module": "myModule",
+			},
+		},
+		{
+			name:        "ModuleHooksExecuted",
+			give:        &ModuleHooksExecuted{ModuleName: "myModule", Method: "OnStop", Runtime: time.Millisecond},
+			wantMessage: "OnStop hooks of module executed",
+			wantFields: map[string]interface{}{
+				"module":  "myModule",
+				"runtime": "1ms",
+			},
+		},
+		{
+			name:        "ModuleHooksExecuted/Error",
+			give:        &ModuleHooksExecuted{ModuleName: "myModule", Method: "OnStart", Runtime: time.Millisecond, Err: someError},
+			wantMessage: "OnStart hooks of module failed",
+			wantFields: map[string]interface{}{
+				"module":  "myModule",
+				"runtime": "1ms",
+				"error":   "some error",
+			},
+		},
 		{
 			name:        "Start/Error",
 			give:        &Started{Err: someError},
@@ -347,6 +438,58 @@ func TestZapLogger(t *testing.T) {
 				"signal": "INTERRUPT",
 			},
 		},
+		{
+			name:        "Stopping/Context",
+			give:        &Stopping{Origin: "context"},
+			wantMessage: "context done",
+			wantFields:  map[string]interface{}{},
+		},
+		{
+			name: "Stopping/Shutdowner",
+			give: &Stopping{
+				Signal:   syscall.SIGTERM,
+				Origin:   "shutdowner",
+				Caller:   "main.main (main.go:42)",
+				ExitCode: 2,
+			},
+			wantMessage: "shutdown requested",
+			wantFields: map[string]interface{}{
+				"caller":   "main.main (main.go:42)",
+				"exitCode": int64(2),
+			},
+		},
+		{
+			name: "Stopping/Shutdowner/Reason",
+			give: &Stopping{
+				Signal: syscall.SIGTERM,
+				Origin: "shutdowner",
+				Caller: "main.main (main.go:42)",
+				Reason: someError,
+			},
+			wantMessage: "shutdown requested",
+			wantFields: map[string]interface{}{
+				"caller":   "main.main (main.go:42)",
+				"exitCode": int64(0),
+				"error":    "some error",
+			},
+		},
+		{
+			name: "Stopping/Shutdowner/Error",
+			give: &Stopping{
+				Signal:   syscall.SIGTERM,
+				Origin:   "shutdowner",
+				Caller:   "main.main (main.go:42)",
+				ExitCode: 1,
+				Reason:   someError,
+				Failed:   true,
+			},
+			wantMessage: "shutdown requested",
+			wantFields: map[string]interface{}{
+				"caller":   "main.main (main.go:42)",
+				"exitCode": int64(1),
+				"error":    "some error",
+			},
+		},
 		{
 			name:        "Stopped/Error",
 			give:        &Stopped{Err: someError},
@@ -385,6 +528,39 @@ func TestZapLogger(t *testing.T) {
 				"error": "some error",
 			},
 		},
+		{
+			name: "StartupSummary",
+			give: &StartupSummary{
+				Modules: []ModuleSummary{
+					{Provides: 2, Invokes: 1, Hooks: 1},
+					{ModuleName: "child", Provides: 1, Decorators: 1},
+				},
+				ConstructorRuntime: 3 * time.Millisecond,
+				OnStartRuntime:     time.Millisecond,
+				SlowestConstructors: []FuncRuntime{
+					{Name: "bytes.NewBuffer()", Runtime: 2 * time.Millisecond},
+					{Name: "strings.NewReader()", ModuleName: "child", Runtime: time.Millisecond},
+				},
+				SlowestHooks: []FuncRuntime{
+					{Name: "main.start()", Runtime: time.Millisecond},
+				},
+			},
+			wantMessage: "startup summary",
+			wantFields: map[string]interface{}{
+				"modules":            int64(2),
+				"provides":           int64(3),
+				"decorators":         int64(1),
+				"invokes":            int64(1),
+				"hooks":              int64(1),
+				"constructorRuntime": "3ms",
+				"onStartRuntime":     "1ms",
+				"slowestConstructors": []interface{}{
+					"bytes.NewBuffer() (2ms)",
+					"strings.NewReader() (1ms)",
+				},
+				"slowestHooks": []interface{}{"main.start() (1ms)"},
+			},
+		},
 		{
 			name:        "LoggerInitialized",
 			give:        &LoggerInitialized{ConstructorName: "bytes.NewBuffer()"},
@@ -427,7 +603,7 @@ func TestZapLogger(t *testing.T) {
 
 				logs := observedLogs.TakeAll()
 				// logs are not visible unless they are errors
-				if strings.HasSuffix(tt.name, "/Error") {
+				if strings.HasSuffix(tt.name, "/Error") || strings.HasSuffix(tt.name, "/Warn") {
 					require.Len(t, logs, 1)
 					got := logs[0]
 					assert.Equal(t, tt.wantMessage, got.Message)
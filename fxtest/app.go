@@ -22,8 +22,11 @@ package fxtest
 
 import (
 	"context"
+	"time"
 
 	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/goleak"
 )
 
 // App is a wrapper around fx.App that provides some testing helpers. By
@@ -32,29 +35,71 @@ type App struct {
 	*fx.App
 
 	tb TB
+
+	// Set if the application was built with VerifyNoLeaks.
+	leakOpts []goleak.Option
+
+	// Values passed to Override.
+	overrides []overridden
+
+	// Errors from options that couldn't be applied.
+	optionErrs []error
 }
 
 // New creates a new test application.
 func New(tb TB, opts ...fx.Option) *App {
-	allOpts := make([]fx.Option, 0, len(opts)+1)
-	allOpts = append(allOpts, WithTestLogger(tb))
+	app := &App{tb: tb}
+
+	allOpts := make([]fx.Option, 0, len(opts)+2)
+	allOpts = append(allOpts,
+		// Must come first to see fxtest options wherever they are.
+		fxbridge.Fx().TestApp(app.applyOption).(fx.Option),
+		WithTestLogger(tb),
+	)
 	allOpts = append(allOpts, opts...)
 
-	app := fx.New(allOpts...)
+	app.App = fx.New(allOpts...)
+	for _, err := range app.optionErrs {
+		tb.Errorf("%v", err)
+	}
 	if err := app.Err(); err != nil {
 		tb.Errorf("fx.New failed: %v", err)
+	}
+	if len(app.optionErrs) > 0 || app.Err() != nil {
 		tb.FailNow()
 	}
+	return app
+}
 
-	return &App{
-		App: app,
-		tb:  tb,
+// applyOption is called with the value of each option built by
+// testOption while the application is being built,
+// and the scope of the module the option was given to.
+func (app *App) applyOption(value, scope interface{}) {
+	switch v := value.(type) {
+	case verifyNoLeaks:
+		app.verifyNoLeaks(v)
+	case override:
+		app.override(v, scope)
 	}
 }
 
+// testOption builds an option that passes value to the App's applyOption,
+// even if it's nested in fx.Options or fx.Module.
+// Applications not built by New fail to build.
+func testOption(name string, value interface{}) fx.Option {
+	return fxbridge.Fx().TestOption(name, value).(fx.Option)
+}
+
+// withTimeout returns a context that's cancelled after d
+// according to the application's clock, which is set by [WithClock].
+func (app *App) withTimeout(d time.Duration) (context.Context, context.CancelFunc) {
+	return fxbridge.Fx().Clock(app.App).WithTimeout(context.Background(), d)
+}
+
 // RequireStart calls Start, failing the test if an error is encountered.
+// The start timeout is measured by the clock set with [WithClock], if any.
 func (app *App) RequireStart() *App {
-	startCtx, cancel := context.WithTimeout(context.Background(), app.StartTimeout())
+	startCtx, cancel := app.withTimeout(app.StartTimeout())
 	defer cancel()
 
 	if err := app.Start(startCtx); err != nil {
@@ -65,12 +110,55 @@ func (app *App) RequireStart() *App {
 }
 
 // RequireStop calls Stop, failing the test if an error is encountered.
+// The stop timeout is measured by the clock set with [WithClock], if any.
+// If the application was built with [VerifyNoLeaks],
+// it also fails the test if the application leaked goroutines,
+// and if it was built with [Override],
+// if any of the overridden values was never injected.
 func (app *App) RequireStop() {
-	stopCtx, cancel := context.WithTimeout(context.Background(), app.StopTimeout())
+	stopCtx, cancel := app.withTimeout(app.StopTimeout())
 	defer cancel()
 
 	if err := app.Stop(stopCtx); err != nil {
 		app.tb.Errorf("application didn't stop cleanly: %v", err)
 		app.tb.FailNow()
+		return
+	}
+	app.requireOverridesUsed()
+	app.requireNoLeaks()
+}
+
+// RequireShutdown waits for the application to be shut down,
+// e.g. by a call to [fx.Shutdowner.Shutdown],
+// and returns the signal it was shut down with.
+// It fails the test if that doesn't happen within the given timeout.
+//
+// The signal may have been sent before RequireShutdown was called.
+func (app *App) RequireShutdown(timeout time.Duration) fx.ShutdownSignal {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case sig := <-app.Wait():
+		return sig
+	case <-timer.C:
+		app.tb.Errorf("application didn't shut down within %v", timeout)
+		app.tb.FailNow()
+		return fx.ShutdownSignal{}
+	}
+}
+
+// RequireNoShutdown waits for the given duration,
+// failing the test if the application is shut down in the meantime.
+func (app *App) RequireNoShutdown(d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case sig := <-app.Wait():
+		app.tb.Errorf("application shut down unexpectedly: signal %v, exit code %v, reason %v",
+			sig.Signal, sig.ExitCode, sig.Reason)
+		app.tb.FailNow()
+	case <-timer.C:
 	}
 }
@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/fx"
@@ -78,6 +79,32 @@ func TestApp(t *testing.T) {
 		assert.Contains(t, spy.errors.String(), "didn't start cleanly", "Expected to write errors to TB.")
 	})
 
+	t.Run("StartTimeoutWithClock", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		clock := NewClock()
+		app := New(
+			spy,
+			WithClock(clock),
+			fx.StartTimeout(time.Hour),
+			BlockHook(HookSelector{Method: "OnStart"}),
+			fx.Invoke(func(lc fx.Lifecycle) {
+				lc.Append(fx.StartHook(func() {}))
+			}),
+		)
+
+		go func() {
+			// Wait for the start context before advancing past its deadline.
+			clock.AwaitScheduled(1)
+			clock.Add(time.Hour)
+		}()
+		app.RequireStart()
+
+		assert.Equal(t, 1, spy.failures, "Expected app to time out on start.")
+		assert.Contains(t, spy.errors.String(), "context deadline exceeded")
+	})
+
 	t.Run("StopFailure", func(t *testing.T) {
 		t.Parallel()
 
@@ -96,4 +123,57 @@ func TestApp(t *testing.T) {
 		assert.Equal(t, 1, spy.failures, "Expected Stop to fail.")
 		assert.Contains(t, spy.errors.String(), "didn't stop cleanly", "Expected to write errors to TB.")
 	})
+
+	t.Run("RequireShutdown", func(t *testing.T) {
+		t.Parallel()
+
+		var s fx.Shutdowner
+		app := New(t, fx.Populate(&s)).RequireStart()
+		defer app.RequireStop()
+
+		reason := errors.New("great sadness")
+		go s.Shutdown(fx.ExitCode(3), fx.ShutdownReason(reason))
+
+		sig := app.RequireShutdown(time.Second)
+		assert.Equal(t, 3, sig.ExitCode)
+		assert.Same(t, reason, sig.Reason)
+	})
+
+	t.Run("RequireShutdownTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		app := New(spy).RequireStart()
+		defer app.RequireStop()
+
+		app.RequireShutdown(time.Millisecond)
+		assert.Equal(t, 1, spy.failures, "Expected RequireShutdown to fail.")
+		assert.Contains(t, spy.errors.String(), "didn't shut down within 1ms")
+	})
+
+	t.Run("RequireNoShutdown", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		app := New(spy).RequireStart()
+		defer app.RequireStop()
+
+		app.RequireNoShutdown(time.Millisecond)
+		assert.Zero(t, spy.failures)
+	})
+
+	t.Run("RequireNoShutdownFailure", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		var s fx.Shutdowner
+		app := New(spy, fx.Populate(&s)).RequireStart()
+		defer app.RequireStop()
+
+		assert.NoError(t, s.Shutdown(fx.ExitCode(2)))
+		app.RequireNoShutdown(time.Second)
+		assert.Equal(t, 1, spy.failures, "Expected RequireNoShutdown to fail.")
+		assert.Contains(t, spy.errors.String(), "shut down unexpectedly")
+		assert.Contains(t, spy.errors.String(), "exit code 2")
+	})
 }
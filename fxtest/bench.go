@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"go.uber.org/fx"
+)
+
+// BenchmarkApp benchmarks building, starting, and stopping an application
+// with the given options, b.N times.
+//
+//	func BenchmarkServer(b *testing.B) {
+//		fxtest.BenchmarkApp(b, server.Module)
+//	}
+//
+// Along with the usual results for the whole loop,
+// it reports the time and allocations of each phase with [testing.B.ReportMetric]:
+// new-ns/op and new-allocs/op for [fx.New],
+// start-ns/op and start-allocs/op for [fx.App.Start],
+// and stop-ns/op and stop-allocs/op for [fx.App.Stop].
+// Counting allocations for each phase adds to the time reported for the
+// whole loop, but not to that of the phases.
+//
+// Fx events are discarded unless the options include [fx.WithLogger].
+// The benchmark fails if the application fails to build, start, or stop.
+func BenchmarkApp(b *testing.B, opts ...fx.Option) {
+	b.Helper()
+
+	allOpts := make([]fx.Option, 0, len(opts)+1)
+	allOpts = append(allOpts, fx.NopLogger)
+	allOpts = append(allOpts, opts...)
+
+	var newPhase, startPhase, stopPhase benchPhase
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var app *fx.App
+		newPhase.measure(func() { app = fx.New(allOpts...) })
+		if err := app.Err(); err != nil {
+			b.Fatalf("fx.New failed: %v", err)
+		}
+
+		var err error
+		startPhase.measure(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), app.StartTimeout())
+			defer cancel()
+			err = app.Start(ctx)
+		})
+		if err != nil {
+			b.Fatalf("application didn't start cleanly: %v", err)
+		}
+
+		stopPhase.measure(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), app.StopTimeout())
+			defer cancel()
+			err = app.Stop(ctx)
+		})
+		if err != nil {
+			b.Fatalf("application didn't stop cleanly: %v", err)
+		}
+	}
+	b.StopTimer()
+
+	newPhase.report(b, "new")
+	startPhase.report(b, "start")
+	stopPhase.report(b, "stop")
+}
+
+// benchPhase accumulates the time and allocations of a phase of BenchmarkApp.
+type benchPhase struct {
+	elapsed time.Duration
+	allocs  uint64
+}
+
+func (p *benchPhase) measure(f func()) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	allocs := mem.Mallocs
+
+	start := time.Now()
+	f()
+	p.elapsed += time.Since(start)
+
+	runtime.ReadMemStats(&mem)
+	p.allocs += mem.Mallocs - allocs
+}
+
+func (p *benchPhase) report(b *testing.B, name string) {
+	b.ReportMetric(float64(p.elapsed.Nanoseconds())/float64(b.N), name+"-ns/op")
+	b.ReportMetric(float64(p.allocs)/float64(b.N), name+"-allocs/op")
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+)
+
+func TestBenchmarkApp(t *testing.T) {
+	t.Parallel()
+
+	var started, stopped int
+	result := testing.Benchmark(func(b *testing.B) {
+		BenchmarkApp(b,
+			fx.Provide(func() *bytes.Buffer { return new(bytes.Buffer) }),
+			fx.Invoke(func(lc fx.Lifecycle, _ *bytes.Buffer) {
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						started++
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						stopped++
+						return nil
+					},
+				})
+			}),
+		)
+	})
+
+	assert.Positive(t, result.N)
+	assert.Equal(t, started, stopped)
+	assert.GreaterOrEqual(t, started, result.N)
+	for _, metric := range []string{
+		"new-ns/op", "new-allocs/op",
+		"start-ns/op", "start-allocs/op",
+		"stop-ns/op", "stop-allocs/op",
+	} {
+		assert.Contains(t, result.Extra, metric)
+	}
+	assert.Positive(t, result.Extra["new-allocs/op"])
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/fx/internal/fxclock"
+)
+
+// Clock is a virtual clock for tests.
+// Time only passes when the test calls [Clock.Add],
+// so tests of timeouts can run instantly and deterministically.
+//
+// Use [WithClock] to make an application use the clock for its start,
+// stop, and hook timeouts, and inject the same clock into components
+// that need one.
+//
+//	clock := fxtest.NewClock()
+//	app := fx.New(
+//		fxtest.WithClock(clock),
+//		fx.Supply(clock),
+//		...
+//	)
+type Clock struct {
+	mock *fxclock.Mock
+}
+
+// NewClock builds a new Clock, starting at the current time.
+func NewClock() *Clock {
+	return &Clock{mock: fxclock.NewMock()}
+}
+
+// WithClock is an [fx.Option] that makes the application use the given
+// clock for timeouts, such as those set by [fx.StartTimeout],
+// [fx.StopTimeout], and [fx.SlowThreshold].
+//
+// [App.RequireStart] and [App.RequireStop] use the clock for their
+// contexts, but it doesn't affect contexts passed directly to
+// [fx.App.Start] or [fx.App.Stop]; use [Clock.WithTimeout] to build those.
+func WithClock(clock *Clock) fx.Option {
+	return fxbridge.Fx().WithClock(clock.mock).(fx.Option)
+}
+
+// Now reports the current time of the clock.
+func (c *Clock) Now() time.Time {
+	return c.mock.Now()
+}
+
+// Since reports the time elapsed since t according to the clock.
+func (c *Clock) Since(t time.Time) time.Duration {
+	return c.mock.Since(t)
+}
+
+// Sleep blocks until the clock is advanced past now + d.
+func (c *Clock) Sleep(d time.Duration) {
+	c.mock.Sleep(d)
+}
+
+// After returns a channel that receives the time now + d
+// once the clock is advanced past it.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	return c.mock.After(d)
+}
+
+// WithTimeout returns a copy of ctx that's cancelled
+// when the clock is advanced past now + d.
+func (c *Clock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return c.mock.WithTimeout(ctx, d)
+}
+
+// Add advances the clock by d, resolving timeouts, sleeps,
+// and other operations that were waiting for the time to pass.
+//
+// Panics if d is negative.
+func (c *Clock) Add(d time.Duration) {
+	c.mock.Add(d)
+}
+
+// AwaitScheduled blocks until at least n operations,
+// such as timeouts or sleeps, have been scheduled on the clock.
+// Use it to wait for code under test to start waiting before calling Add.
+func (c *Clock) AwaitScheduled(n int) {
+	c.mock.AwaitScheduled(n)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("start timeout", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewClock()
+		app := fx.New(
+			// Hooks that time out keep running and logging
+			// after the test ends, so don't log to t.
+			fx.NopLogger,
+			WithClock(clock),
+			fx.StartTimeout(time.Minute),
+			fx.Invoke(func(lc fx.Lifecycle) {
+				lc.Append(fx.StartHook(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}))
+			}),
+		)
+
+		go func() {
+			clock.AwaitScheduled(1)
+			clock.Add(time.Minute)
+		}()
+		assert.ErrorIs(t, app.RunContext(context.Background()), context.DeadlineExceeded)
+	})
+
+	t.Run("stop timeout", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewClock()
+		app := fx.New(
+			// Hooks that time out keep running and logging
+			// after the test ends, so don't log to t.
+			fx.NopLogger,
+			WithClock(clock),
+			fx.StopTimeout(time.Minute),
+			fx.Invoke(func(lc fx.Lifecycle, sd fx.Shutdowner) {
+				lc.Append(fx.StopHook(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}))
+				sd.Shutdown()
+			}),
+		)
+
+		go func() {
+			// Wait for the start and stop timeouts.
+			clock.AwaitScheduled(2)
+			clock.Add(time.Minute)
+		}()
+		assert.ErrorIs(t, app.RunContext(context.Background()), context.DeadlineExceeded)
+	})
+
+	t.Run("injected into components", func(t *testing.T) {
+		t.Parallel()
+
+		type ticker struct{ ticked chan time.Time }
+
+		clock := NewClock()
+		var tk *ticker
+		app := New(t,
+			WithClock(clock),
+			fx.Supply(clock),
+			fx.Provide(func(lc fx.Lifecycle, clock *Clock) *ticker {
+				tk := &ticker{ticked: make(chan time.Time, 1)}
+				lc.Append(fx.StartHook(func() {
+					go func() {
+						tk.ticked <- <-clock.After(time.Hour)
+					}()
+				}))
+				return tk
+			}),
+			fx.Populate(&tk),
+		)
+		defer app.RequireStart().RequireStop()
+
+		start := clock.Now()
+		// Wait for the start timeout of RequireStart and the ticker.
+		clock.AwaitScheduled(2)
+		clock.Add(time.Hour)
+		assert.Equal(t, start.Add(time.Hour), <-tk.ticked)
+		assert.Equal(t, time.Hour, clock.Since(start))
+	})
+
+	t.Run("sleep and timeout", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewClock()
+		ctx, cancel := clock.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			clock.Sleep(time.Second)
+		}()
+
+		clock.AwaitScheduled(2)
+		require.NoError(t, ctx.Err())
+		clock.Add(time.Second)
+		<-done
+		assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	})
+}
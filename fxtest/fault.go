@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+// HookSelector chooses the lifecycle hooks that a fault applies to.
+// Empty fields match all hooks.
+//
+//	// Fail the OnStart hook of any function named like "Server.Start"
+//	// appended by the "http" module.
+//	fxtest.FailHook(fxtest.HookSelector{
+//		Method:   "OnStart",
+//		Function: "Server.Start",
+//		Module:   "http",
+//	}, errors.New("great sadness"))
+type HookSelector struct {
+	// Method is "OnStart" or "OnStop".
+	Method string
+
+	// Function matches hooks whose function name contains it.
+	// This is the name reported in OnStartExecuting
+	// and OnStopExecuting events.
+	Function string
+
+	// Module is the name of the [fx.Module] that appended the hook.
+	Module string
+}
+
+func (s HookSelector) matches(call lifecycle.HookCall) bool {
+	return (s.Method == "" || s.Method == call.Method) &&
+		strings.Contains(call.FunctionName, s.Function) &&
+		(s.Module == "" || s.Module == call.ModuleName)
+}
+
+// FailHook is an [fx.Option] that makes the selected hooks return err
+// instead of running.
+//
+// Use it to test how an application handles a failing hook:
+// a failed OnStart hook makes Fx roll back the start,
+// running the OnStop hooks of the hooks that had already started.
+func FailHook(sel HookSelector, err error) fx.Option {
+	return injectFault(sel, func(lifecycle.HookCall) func(context.Context) error {
+		return func(context.Context) error {
+			return err
+		}
+	})
+}
+
+// BlockHook is an [fx.Option] that makes the selected hooks block
+// instead of running, until their context is done.
+// The hooks then return the context's error.
+//
+// Use it to test how an application handles a hook that runs past its
+// deadline, e.g. one set with [fx.StartTimeout] or [fx.StopTimeout].
+// Combine it with [WithClock] to reach the deadline of
+// [App.RequireStart] or [App.RequireStop] without waiting.
+func BlockHook(sel HookSelector) fx.Option {
+	return injectFault(sel, func(lifecycle.HookCall) func(context.Context) error {
+		return func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+	})
+}
+
+// injectFault builds an option that replaces the selected hooks
+// with the function that fault returns for them.
+func injectFault(sel HookSelector, fault func(lifecycle.HookCall) func(context.Context) error) fx.Option {
+	return fxbridge.Fx().InterceptHooks(func(call lifecycle.HookCall, fn func(context.Context) error) func(context.Context) error {
+		if sel.matches(call) {
+			return fault(call)
+		}
+		return fn
+	}).(fx.Option)
+}
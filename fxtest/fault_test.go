@@ -0,0 +1,146 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+func startCache(context.Context) error { return nil }
+func stopCache(context.Context) error  { return nil }
+
+func TestHookFaults(t *testing.T) {
+	t.Parallel()
+
+	// servers builds modules "a", "b", and "c", each appending a hook
+	// that records when it starts and stops.
+	servers := func(ran *[]string) fx.Option {
+		var opts []fx.Option
+		for _, name := range []string{"a", "b", "c"} {
+			name := name
+			opts = append(opts, fx.Module(name,
+				fx.Invoke(func(lc fx.Lifecycle) {
+					lc.Append(fx.Hook{
+						OnStart: func(context.Context) error {
+							*ran = append(*ran, "start "+name)
+							return nil
+						},
+						OnStop: func(context.Context) error {
+							*ran = append(*ran, "stop "+name)
+							return nil
+						},
+					})
+				}),
+			))
+		}
+		return fx.Options(opts...)
+	}
+
+	t.Run("fail OnStart by module", func(t *testing.T) {
+		t.Parallel()
+
+		var ran []string
+		spy := NewEventSpy(t)
+		giveErr := errors.New("great sadness")
+		app := New(t,
+			spy.Option(),
+			servers(&ran),
+			FailHook(HookSelector{Method: "OnStart", Module: "c"}, giveErr),
+		)
+
+		err := app.Start(context.Background())
+		assert.ErrorIs(t, err, giveErr)
+		assert.Equal(t, []string{"start a", "start b", "stop b", "stop a"}, ran)
+		assert.Len(t, spy.EventsOfType("RollingBack"), 1)
+		assert.Len(t, spy.EventsOfType("RolledBack"), 1)
+	})
+
+	t.Run("fail OnStop by function", func(t *testing.T) {
+		t.Parallel()
+
+		giveErr := errors.New("great sadness")
+		app := New(t,
+			fx.Invoke(func(lc fx.Lifecycle) {
+				lc.Append(fx.Hook{OnStart: startCache, OnStop: stopCache})
+			}),
+			FailHook(HookSelector{Function: "stopCache"}, giveErr),
+		)
+
+		require.NoError(t, app.Start(context.Background()))
+		assert.ErrorIs(t, app.Stop(context.Background()), giveErr)
+	})
+
+	t.Run("selector matches nothing", func(t *testing.T) {
+		t.Parallel()
+
+		var ran []string
+		app := New(t,
+			servers(&ran),
+			FailHook(HookSelector{Module: "d"}, errors.New("great sadness")),
+		)
+
+		app.RequireStart().RequireStop()
+		assert.Equal(t, []string{
+			"start a", "start b", "start c",
+			"stop c", "stop b", "stop a",
+		}, ran)
+	})
+
+	t.Run("block past deadline", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu      sync.Mutex
+			stopped []string
+		)
+		app := New(t,
+			// Fx keeps stopping the application in the background
+			// after the deadline, so don't log to t.
+			fx.NopLogger,
+			fx.Invoke(func(lc fx.Lifecycle) {
+				lc.Append(fx.Hook{OnStart: startCache, OnStop: stopCache})
+				lc.Append(fx.StopHook(func() {
+					mu.Lock()
+					defer mu.Unlock()
+					stopped = append(stopped, "last appended")
+				}))
+			}),
+			BlockHook(HookSelector{Function: "stopCache"}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, app.Stop(ctx), context.DeadlineExceeded)
+
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Equal(t, []string{"last appended"}, stopped)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+)
+
+// _updateEnv is the environment variable that makes RequireGraphSnapshot
+// write golden files instead of checking them.
+const _updateEnv = "FXTEST_UPDATE"
+
+// RequireGraphSnapshot fails the test unless the wiring of the given
+// application matches the golden file at path.
+// To write the golden file instead, run the test with FXTEST_UPDATE=1,
+// or with -update if the test package defines a boolean -update flag.
+//
+//	func TestWiring(t *testing.T) {
+//		app := fx.New(server.Module)
+//		fxtest.RequireGraphSnapshot(t, app, "testdata/graph.golden")
+//	}
+//
+// The snapshot lists the application's modules, constructors,
+// decorators (including [fx.Replace]), and invoked functions,
+// the types each of them consumes and produces,
+// and which of them feed into which.
+// Edges respect module scope: a function is only fed by decorators
+// in its module or its ancestors, and by constructors visible to it.
+// It's sorted and doesn't include source locations,
+// so it only changes when the wiring does,
+// e.g. because a dependency upgrade added or replaced a constructor.
+// Values that Fx provides to every application,
+// like [fx.Lifecycle], are attributed to "fx".
+func RequireGraphSnapshot(t TB, app *fx.App, path string) {
+	if err := app.Err(); err != nil {
+		t.Errorf("can't snapshot an application that failed to build: %v", err)
+		t.FailNow()
+		return
+	}
+	got := fxbridge.Fx().GraphSnapshot(app)
+
+	if updateGolden() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("create directory for %v: %v", path, err)
+			t.FailNow()
+			return
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Errorf("write %v: %v", path, err)
+			t.FailNow()
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("golden file %v doesn't exist: run the test with %v=1 to create it", path, _updateEnv)
+		} else {
+			t.Errorf("read %v: %v", path, err)
+		}
+		t.FailNow()
+		return
+	}
+
+	if string(want) != got {
+		t.Errorf("dependency graph doesn't match %v; run the test with %v=1 if this is intended:\n%v",
+			path, _updateEnv, diffLines(splitLines(string(want)), splitLines(got)))
+		t.FailNow()
+	}
+}
+
+// updateGolden reports whether golden files should be written.
+// fxtest doesn't define an -update flag itself, so that it doesn't clash
+// with one defined by the test package.
+func updateGolden() bool {
+	if update, err := strconv.ParseBool(os.Getenv(_updateEnv)); err == nil && update {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		update, err := strconv.ParseBool(f.Value.String())
+		return err == nil && update
+	}
+	return false
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+type (
+	graphConfig  struct{ Addr string }
+	graphHandler struct{}
+	graphServer  struct{}
+)
+
+func newGraphConfig() *graphConfig { return &graphConfig{} }
+
+func newGraphHandler(*bytes.Buffer) *graphHandler { return &graphHandler{} }
+
+type graphServerParams struct {
+	fx.In
+
+	Config   *graphConfig
+	Handlers []*graphHandler `group:"handlers"`
+	Log      io.Writer       `name:"log" optional:"true"`
+}
+
+func newGraphServer(fx.Lifecycle, graphServerParams) *graphServer { return &graphServer{} }
+
+func decorateGraphConfig(cfg *graphConfig) *graphConfig { return &graphConfig{Addr: cfg.Addr + ":80"} }
+
+func registerGraphConfig(*graphConfig) {}
+
+func newGraphApp() *fx.App {
+	return fx.New(
+		fx.NopLogger,
+		fx.Module("http",
+			fx.Provide(
+				newGraphServer,
+				fx.Annotate(newGraphHandler, fx.ResultTags(`group:"handlers"`)),
+			),
+			fx.Decorate(decorateGraphConfig),
+			fx.Replace(new(bytes.Buffer)),
+			fx.Module("config",
+				fx.Provide(newGraphConfig),
+			),
+		),
+		fx.Supply(new(bytes.Buffer)),
+		fx.Provide(fx.Private, func() string { return "unused" }),
+		// Outside of module "http", so it gets the undecorated config.
+		fx.Invoke(registerGraphConfig),
+	)
+}
+
+func TestRequireGraphSnapshot(t *testing.T) {
+	t.Parallel()
+
+	const golden = "testdata/graph.golden"
+
+	t.Run("matches", func(t *testing.T) {
+		t.Parallel()
+
+		RequireGraphSnapshot(t, newGraphApp(), golden)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "graph.golden")
+		want, err := os.ReadFile(golden)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, append(want, "  extra\n"...), 0o644))
+
+		spy := newTB()
+		RequireGraphSnapshot(spy, newGraphApp(), path)
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "dependency graph doesn't match")
+		assert.Contains(t, spy.errors.String(), "-   extra")
+	})
+
+	t.Run("missing golden file", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		RequireGraphSnapshot(spy, newGraphApp(), filepath.Join(t.TempDir(), "graph.golden"))
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "run the test with FXTEST_UPDATE=1 to create it")
+	})
+
+	t.Run("app error", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		app := fx.New(fx.NopLogger, fx.Invoke(func(*graphServer) {}))
+		RequireGraphSnapshot(spy, app, golden)
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "failed to build")
+	})
+}
+
+// fxtest must not define -update itself, or this panics.
+var _update = flag.Bool("update", false, "regenerate golden files")
+
+func TestRequireGraphSnapshotUpdate(t *testing.T) {
+	// Not parallel: changes the environment and the -update flag.
+
+	requireUpdated := func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "nested", "graph.golden")
+		RequireGraphSnapshot(t, newGraphApp(), path)
+
+		got, err := os.ReadFile(path)
+		require.NoError(t, err)
+		want, err := os.ReadFile("testdata/graph.golden")
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got))
+	}
+
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv("FXTEST_UPDATE", "1")
+		requireUpdated(t)
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		defer func(update bool) { *_update = update }(*_update)
+		*_update = true
+		requireUpdated(t)
+	})
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/goleak"
+)
+
+// VerifyNoLeaks is an option for [New] that makes [App.RequireStop] fail
+// the test if the application leaked goroutines.
+//
+//	app := fxtest.New(t, fxtest.VerifyNoLeaks(), server.Module)
+//	app.RequireStart().RequireStop()
+//
+// Goroutines that exist before the application's constructors run are
+// ignored, along with those matched by the given goleak options.
+// Each leaked goroutine is traced back to the lifecycle hook, constructor,
+// decorator, or invoked function that started it, if any.
+//
+// VerifyNoLeaks may be nested in [fx.Options] or [fx.Module],
+// but the application must be built with [New]:
+// [fx.New] fails with an error otherwise.
+// As with goleak, goroutines started by other tests running in parallel
+// are reported as leaks, so don't use it in parallel tests.
+func VerifyNoLeaks(opts ...goleak.Option) fx.Option {
+	return testOption("fxtest.VerifyNoLeaks", verifyNoLeaks{opts: opts})
+}
+
+// verifyNoLeaks is passed to App.applyOption by VerifyNoLeaks.
+type verifyNoLeaks struct {
+	opts []goleak.Option
+}
+
+// verifyNoLeaks makes RequireStop verify the application with the goleak
+// options of v. It's called before the application's constructors run.
+func (app *App) verifyNoLeaks(v verifyNoLeaks) {
+	if app.leakOpts == nil {
+		app.leakOpts = []goleak.Option{goleak.IgnoreCurrent()}
+	}
+	app.leakOpts = append(app.leakOpts, v.opts...)
+}
+
+// requireNoLeaks fails the test if goroutines leaked,
+// if the application was built with VerifyNoLeaks.
+func (app *App) requireNoLeaks() {
+	if app.leakOpts == nil {
+		return
+	}
+	err := goleak.Find(app.leakOpts...)
+	if err == nil {
+		return
+	}
+
+	desc := describeLeaks(leakedGoroutineIDs(err), goroutineCreators(), fxbridge.Fx().GraphFuncs(app.App))
+	if desc == "" {
+		// goleak's message couldn't be parsed, but it's still the best report.
+		app.tb.Errorf("application leaked goroutines:\n%v", err)
+	} else {
+		app.tb.Errorf("application leaked goroutines:\n%v\n\n%v", desc, err)
+	}
+	app.tb.FailNow()
+}
+
+var (
+	// Matches the start of each goroutine in goleak's error message.
+	//
+	//	Goroutine 42 in state chan receive, with example.run on top of the stack:
+	_leakedGoroutineRe = regexp.MustCompile(`(?m)^\[?Goroutine (\d+) in state`)
+
+	// Matches the start of each goroutine in the output of runtime.Stack.
+	//
+	//	goroutine 42 [chan receive]:
+	_goroutineRe = regexp.MustCompile(`^goroutine (\d+) \[`)
+
+	// Matches the function that started a goroutine in its stack trace.
+	//
+	//	created by example.start in goroutine 7
+	_createdByRe = regexp.MustCompile(`(?m)^created by (\S+)`)
+)
+
+// leakedGoroutineIDs returns the IDs of the goroutines that goleak reported.
+//
+// goleak doesn't expose the goroutines it finds, only its error message,
+// so this returns nil if the format of the message changes.
+func leakedGoroutineIDs(err error) []int {
+	var ids []int
+	for _, m := range _leakedGoroutineRe.FindAllStringSubmatch(err.Error(), -1) {
+		if id, err := strconv.Atoi(m[1]); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// goroutineCreators returns the name of the function that started each
+// running goroutine, by goroutine ID.
+func goroutineCreators() map[int]string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true /* all */)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	creators := make(map[int]string)
+	for _, stack := range strings.Split(string(buf), "\n\n") {
+		m := _goroutineRe.FindStringSubmatch(stack)
+		if m == nil {
+			continue
+		}
+		id, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		if c := _createdByRe.FindStringSubmatch(stack); c != nil {
+			creators[id] = c[1]
+		}
+	}
+	return creators
+}
+
+// describeLeaks lists the leaked goroutines with the given IDs,
+// and which of funcs started each one, given the creators of goroutines.
+func describeLeaks(ids []int, creators map[int]string, funcs []fxbridge.Func) string {
+	lines := make([]string, 0, len(ids))
+	for _, id := range ids {
+		creator, ok := creators[id]
+		if !ok {
+			// It exited after goleak found it, or wasn't started by a function.
+			lines = append(lines, fmt.Sprintf("  goroutine %v", id))
+			continue
+		}
+
+		line := fmt.Sprintf("  goroutine %v started by %v", id, creator)
+		if f, ok := findCreator(creator, funcs); ok {
+			line += fmt.Sprintf(" in %v %v", f.Kind, f.Name)
+			if f.ModuleName != "" {
+				line += fmt.Sprintf(" from module %q", f.ModuleName)
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findCreator finds the function that the function named creator,
+// which started a goroutine, is part of.
+// Functions nested in others are named after them,
+// so this picks the function with the longest matching name.
+//
+//	example.newServer.func1.1 is part of example.newServer.func1
+func findCreator(creator string, funcs []fxbridge.Func) (fxbridge.Func, bool) {
+	var (
+		found   fxbridge.Func
+		longest int
+	)
+	for _, f := range funcs {
+		name := funcRuntimeName(f.Name)
+		if name == "" || len(name) <= longest {
+			continue
+		}
+		if creator == name || strings.HasPrefix(creator, name+".") {
+			found, longest = f, len(name)
+		}
+	}
+	return found, longest > 0
+}
+
+// funcRuntimeName converts the name of a function reported in fxevent events
+// back to the name the runtime uses in stack traces.
+//
+//	example.newServer()                      => example.newServer
+//	example.(*Server).Start-fm()             => example.(*Server).Start
+//	fx.Annotate(example.newServer(), ...)    => example.newServer
+func funcRuntimeName(name string) string {
+	name = strings.TrimPrefix(name, "fx.Annotate(")
+	name, _, _ = strings.Cut(name, "()")
+	return strings.TrimSuffix(name, "-fm")
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/goleak"
+)
+
+// These tests aren't parallel:
+// goroutines started by other tests would be reported as leaks.
+
+type leakyWorker struct{ done chan struct{} }
+
+func newLeakyWorker() *leakyWorker {
+	w := &leakyWorker{done: make(chan struct{})}
+	go func() { <-w.done }()
+	return w
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	t.Run("no leaks", func(t *testing.T) {
+		spy := newTB()
+		New(spy,
+			VerifyNoLeaks(),
+			fx.Invoke(func(lc fx.Lifecycle) {
+				done := make(chan struct{})
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						go func() { <-done }()
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						close(done)
+						return nil
+					},
+				})
+			}),
+		).RequireStart().RequireStop()
+
+		assert.Zero(t, spy.failures, spy.errors.String())
+	})
+
+	t.Run("leak from hook", func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+
+		spy := newTB()
+		New(spy,
+			VerifyNoLeaks(),
+			fx.Module("worker",
+				fx.Invoke(func(lc fx.Lifecycle) {
+					lc.Append(fx.StartHook(func() {
+						go func() { <-done }()
+					}))
+				}),
+			),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "application leaked goroutines")
+		assert.Regexp(t,
+			`goroutine \d+ started by go.uber.org/fx/fxtest.TestVerifyNoLeaks.func2.1.1 `+
+				`in OnStart hook go.uber.org/fx/fxtest.TestVerifyNoLeaks.func2.1.1\(\) from module "worker"`,
+			spy.errors.String())
+	})
+
+	t.Run("leak from constructor", func(t *testing.T) {
+		var w *leakyWorker
+		defer func() { close(w.done) }()
+
+		spy := newTB()
+		New(spy,
+			VerifyNoLeaks(),
+			fx.Provide(newLeakyWorker),
+			fx.Populate(&w),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, 1, spy.failures)
+		assert.Regexp(t,
+			`goroutine \d+ started by go.uber.org/fx/fxtest.newLeakyWorker `+
+				`in constructor go.uber.org/fx/fxtest.newLeakyWorker\(\)\n`,
+			spy.errors.String())
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		var w *leakyWorker
+		defer func() { close(w.done) }()
+
+		spy := newTB()
+		New(spy,
+			fx.Module("worker",
+				fx.Options(VerifyNoLeaks()),
+				fx.Provide(newLeakyWorker),
+			),
+			fx.Populate(&w),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "application leaked goroutines")
+	})
+
+	t.Run("without fxtest.New", func(t *testing.T) {
+		app := fx.New(fx.NopLogger, fx.Module("worker", VerifyNoLeaks()))
+		assert.ErrorContains(t, app.Err(),
+			"fxtest.VerifyNoLeaks Option should be passed to fxtest.New, not to fx.New")
+	})
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, "fxtest.VerifyNoLeaks()", VerifyNoLeaks().String())
+	})
+}
+
+// Pins the format of goleak's error message,
+// which leakedGoroutineIDs relies on.
+func TestLeakedGoroutineIDs(t *testing.T) {
+	ignore := goleak.IgnoreCurrent()
+
+	done := make(chan struct{})
+	defer close(done)
+	ids := make(chan int)
+	go func() {
+		ids <- currentGoroutineID(t)
+		<-done
+	}()
+	id := <-ids
+
+	err := goleak.Find(ignore)
+	require.Error(t, err)
+	assert.Equal(t, []int{id}, leakedGoroutineIDs(err))
+	assert.Contains(t, goroutineCreators()[id], "TestLeakedGoroutineIDs")
+
+	assert.Empty(t, leakedGoroutineIDs(errors.New("found unexpected goroutines")))
+}
+
+// currentGoroutineID returns the ID of the calling goroutine.
+func currentGoroutineID(t *testing.T) int {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false /* all */)]
+	m := _goroutineRe.FindSubmatch(buf)
+	require.NotNil(t, m, "unexpected stack: %s", buf)
+	id, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	return id
+}
+
+func TestDescribeLeaks(t *testing.T) {
+	t.Parallel()
+
+	funcs := []fxbridge.Func{
+		{Kind: "constructor", Name: "example.newServer()", ModuleName: "http"},
+	}
+	creators := map[int]string{
+		7: "example.newServer.func1",
+		8: "example.other",
+	}
+	assert.Equal(t,
+		"  goroutine 7 started by example.newServer.func1 "+
+			`in constructor example.newServer() from module "http"`+"\n"+
+			"  goroutine 8 started by example.other\n"+
+			"  goroutine 9",
+		describeLeaks([]int{7, 8, 9}, creators, funcs))
+	assert.Empty(t, describeLeaks(nil, creators, funcs))
+}
+
+func TestFindCreator(t *testing.T) {
+	t.Parallel()
+
+	funcs := []fxbridge.Func{
+		{Kind: "constructor", Name: "example.newServer()"},
+		{Kind: "OnStart hook", Name: "example.newServer.func1()", ModuleName: "http"},
+		{Kind: "OnStop hook", Name: "example.(*Server).Stop-fm()"},
+		{Kind: "constructor", Name: `fx.Annotate(example.newClient(), fx.ResultTags(["name:\"c\""])`},
+	}
+
+	tests := []struct {
+		creator string
+		want    string // kind of the matching function, empty if none
+	}{
+		{"example.newServer", "constructor"},
+		{"example.newServer.func2", "constructor"},
+		{"example.newServer.func1", "OnStart hook"},
+		{"example.newServer.func1.1", "OnStart hook"},
+		{"example.(*Server).Stop.func1", "OnStop hook"},
+		{"example.newClient", "constructor"},
+		{"example.newServerPool", ""},
+		{"other.newServer", ""},
+	}
+	for _, tt := range tests {
+		f, ok := findCreator(tt.creator, funcs)
+		assert.Equal(t, tt.want != "", ok, tt.creator)
+		assert.Equal(t, tt.want, f.Kind, tt.creator)
+	}
+}
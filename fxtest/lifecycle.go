@@ -25,8 +25,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
 
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxbridge"
 	"go.uber.org/fx/internal/fxclock"
 	"go.uber.org/fx/internal/fxlog"
 	"go.uber.org/fx/internal/lifecycle"
@@ -99,6 +103,9 @@ type Lifecycle struct {
 	lc *lifecycle.Lifecycle
 
 	enforceTimeout bool
+
+	mu   sync.Mutex
+	runs []HookRun
 }
 
 var _ fx.Lifecycle = (*Lifecycle)(nil)
@@ -112,10 +119,11 @@ func NewLifecycle(t TB, opts ...LifecycleOption) *Lifecycle {
 		w = os.Stderr
 		t = &panicT{W: os.Stderr}
 	}
-	lc := &Lifecycle{
-		lc: lifecycle.New(fxlog.DefaultLogger(w), fxclock.System),
-		t:  t,
-	}
+	lc := &Lifecycle{t: t}
+	lc.lc = lifecycle.New(
+		fxevent.Tee(fxlog.DefaultLogger(w), hookRunLogger{lc}),
+		fxclock.System,
+	)
 	for _, opt := range opts {
 		opt.apply(lc)
 	}
@@ -189,8 +197,95 @@ func (l *Lifecycle) RequireStop() {
 
 // Append registers a new Hook.
 func (l *Lifecycle) Append(h fx.Hook) {
-	l.lc.Append(lifecycle.Hook{
-		OnStart: h.OnStart,
-		OnStop:  h.OnStop,
-	})
+	l.lc.Append(fxbridge.Fx().LifecycleHook(h))
+}
+
+// HookInfo describes a hook appended to a [Lifecycle].
+type HookInfo struct {
+	OnStartName string // empty if the hook has no OnStart function
+	OnStopName  string // empty if the hook has no OnStop function
+
+	// Function, file, and line that appended the hook.
+	CallerName string
+	CallerFile string
+	CallerLine int
+
+	// Started reports whether the hook's OnStart function succeeded
+	// during the most recent call to Start.
+	// Hooks without an OnStart function are started along with the
+	// hooks before them.
+	Started bool
+}
+
+// Hooks returns the hooks appended to the lifecycle,
+// in the order they were appended.
+func (l *Lifecycle) Hooks() []HookInfo {
+	infos := l.lc.Hooks()
+	hooks := make([]HookInfo, len(infos))
+	for i, info := range infos {
+		hooks[i] = HookInfo{
+			OnStartName: info.OnStartName,
+			OnStopName:  info.OnStopName,
+			CallerName:  info.CallerFrame.Function,
+			CallerFile:  info.CallerFrame.File,
+			CallerLine:  info.CallerFrame.Line,
+			Started:     info.Started,
+		}
+	}
+	return hooks
+}
+
+// HooksRun returns the OnStart and OnStop hooks that ran
+// since the lifecycle was created, in the order they ran.
+func (l *Lifecycle) HooksRun() []HookRun {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]HookRun(nil), l.runs...)
+}
+
+// RequireHookOrder fails the test unless hooks matching the given names ran
+// in that order. Other hooks may have run in between.
+//
+// A name matches hooks whose function name contains it.
+// Names starting with "OnStart " or "OnStop " only match hooks of that method.
+//
+//	lc.RequireHookOrder("OnStart startDB", "OnStart startServer", "OnStop stopDB")
+//
+// The failure reports the first name that had no match.
+func (l *Lifecycle) RequireHookOrder(names ...string) {
+	runs := l.HooksRun()
+	matched := matchInOrder(runs, names, matchHookRun)
+	if len(matched) == len(names) {
+		return
+	}
+
+	got := make([]string, len(runs))
+	for i, r := range runs {
+		got[i] = r.Method + " " + r.FunctionName
+	}
+	l.t.Errorf("hooks didn't run in order:\n%v", describeOrder(names, got, matched))
+	l.t.FailNow()
+}
+
+func matchHookRun(r HookRun, name string) bool {
+	for _, method := range []string{"OnStart", "OnStop"} {
+		if fn, ok := strings.CutPrefix(name, method+" "); ok {
+			return r.Method == method && strings.Contains(r.FunctionName, fn)
+		}
+	}
+	return strings.Contains(r.FunctionName, name)
+}
+
+// hookRunLogger records the hooks that ran in a Lifecycle.
+type hookRunLogger struct{ l *Lifecycle }
+
+func (r hookRunLogger) LogEvent(e fxevent.Event) {
+	run, ok := hookRunOf(e)
+	if !ok {
+		return
+	}
+
+	r.l.mu.Lock()
+	defer r.l.mu.Unlock()
+	r.l.runs = append(r.l.runs, run)
 }
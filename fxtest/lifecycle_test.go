@@ -317,6 +317,98 @@ func TestPanicT(t *testing.T) {
 	})
 }
 
+func startDB() {}
+func stopDB()  {}
+
+func TestLifecycleInspection(t *testing.T) {
+	t.Parallel()
+
+	newLifecycle := func(tb TB) *Lifecycle {
+		lc := NewLifecycle(tb)
+		lc.Append(fx.StartStopHook(startDB, stopDB))
+		lc.Append(fx.StartHook(startCache))
+		return lc
+	}
+
+	t.Run("Hooks", func(t *testing.T) {
+		t.Parallel()
+
+		lc := newLifecycle(t)
+		hooks := lc.Hooks()
+		require.Len(t, hooks, 2)
+		assert.Equal(t, "go.uber.org/fx/fxtest.startDB()", hooks[0].OnStartName)
+		assert.Equal(t, "go.uber.org/fx/fxtest.stopDB()", hooks[0].OnStopName)
+		assert.Equal(t, "go.uber.org/fx/fxtest.startCache()", hooks[1].OnStartName)
+		assert.Empty(t, hooks[1].OnStopName)
+		for _, h := range hooks {
+			assert.Contains(t, h.CallerName, "TestLifecycleInspection")
+			assert.Contains(t, h.CallerFile, "lifecycle_test.go")
+			assert.Positive(t, h.CallerLine)
+			assert.False(t, h.Started)
+		}
+
+		lc.RequireStart()
+		for _, h := range lc.Hooks() {
+			assert.True(t, h.Started)
+		}
+
+	})
+
+	t.Run("Started after failure", func(t *testing.T) {
+		t.Parallel()
+
+		lc := NewLifecycle(t)
+		lc.Append(fx.StartHook(startDB))
+		lc.Append(fx.StartHook(func() error { return errors.New("great sadness") }))
+		require.Error(t, lc.Start(context.Background()))
+
+		hooks := lc.Hooks()
+		require.Len(t, hooks, 2)
+		assert.True(t, hooks[0].Started)
+		assert.False(t, hooks[1].Started)
+	})
+
+	t.Run("HooksRun", func(t *testing.T) {
+		t.Parallel()
+
+		lc := newLifecycle(t)
+		assert.Empty(t, lc.HooksRun())
+
+		lc.RequireStart().RequireStop()
+		runs := lc.HooksRun()
+		require.Len(t, runs, 3)
+		assert.Equal(t, "OnStart", runs[0].Method)
+		assert.Equal(t, "go.uber.org/fx/fxtest.startDB()", runs[0].FunctionName)
+		assert.Contains(t, runs[0].CallerName, "TestLifecycleInspection")
+		assert.NoError(t, runs[0].Err)
+		assert.Equal(t, "OnStart", runs[1].Method)
+		assert.Equal(t, "go.uber.org/fx/fxtest.startCache()", runs[1].FunctionName)
+		assert.Equal(t, "OnStop", runs[2].Method)
+		assert.Equal(t, "go.uber.org/fx/fxtest.stopDB()", runs[2].FunctionName)
+	})
+
+	t.Run("RequireHookOrder", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		lc := newLifecycle(spy)
+		lc.RequireStart().RequireStop()
+		lc.RequireHookOrder("startDB", "startCache", "stopDB")
+		lc.RequireHookOrder("OnStart startDB", "OnStop stopDB")
+		assert.Zero(t, spy.failures, spy.errors.String())
+
+		lc.RequireHookOrder("startCache", "startDB")
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "hooks didn't run in order:\n"+
+			`"startCache" matched OnStart go.uber.org/fx/fxtest.startCache()`+"\n"+
+			`"startDB" has no match after it`+"\n")
+		assert.Contains(t, spy.errors.String(), "got:\n\tOnStart go.uber.org/fx/fxtest.startDB()\n")
+
+		lc.RequireHookOrder("OnStop startDB")
+		assert.Equal(t, 2, spy.failures)
+	})
+}
+
 func TestMain(m *testing.M) {
 	goleak.VerifyTestMain(m)
 }
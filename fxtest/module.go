@@ -0,0 +1,163 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"reflect"
+	"sort"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/fx/internal/fxdeps"
+)
+
+// ModuleApp is an application that runs a single module under test.
+// Use [Module] to build one.
+type ModuleApp struct {
+	*App
+
+	stubbed []string
+}
+
+// Module builds an application with only the given module and opts,
+// and starts it.
+//
+// Types that the module consumes but doesn't provide itself are taken
+// from opts if they provide them, and filled with zero values otherwise.
+// Use opts to register fakes for the dependencies that matter to the test.
+//
+//	app := fxtest.Module(t, server.Module,
+//		fx.Supply(fakeDB),
+//	)
+//	t.Log(app.Stubbed()) // e.g. [*zap.Logger *metrics.Scope]
+//
+// Optional dependencies and value groups are left as is,
+// and so are the values that Fx provides, like [fx.Lifecycle].
+// Pass [fx.ConstructTimeout] in opts to test modules that consume
+// [fx.ConstructContext].
+// Values that the module provides with [fx.Private] are only visible
+// inside it, so opts that consume them get stubs too.
+//
+// If tb has a Cleanup method, like [testing.T], the application is stopped
+// when the test finishes. Otherwise, call [App.RequireStop].
+func Module(tb TB, mod fx.Option, opts ...fx.Option) *ModuleApp {
+	stubs, err := missingDeps(fx.Options(mod, fx.Options(opts...)))
+	if err != nil {
+		tb.Errorf("fxtest.Module failed: %v", err)
+		tb.FailNow()
+		return nil
+	}
+
+	allOpts := make([]fx.Option, 0, len(opts)+len(stubs)+1)
+	allOpts = append(allOpts, mod)
+	allOpts = append(allOpts, opts...)
+	stubbed := make([]string, len(stubs))
+	for i, k := range stubs {
+		allOpts = append(allOpts, stubOption(k))
+		stubbed[i] = k.String()
+	}
+	if len(stubbed) > 0 {
+		tb.Logf("fxtest.Module: stubbed %v with zero values", stubbed)
+	}
+
+	app := &ModuleApp{
+		App:     New(tb, allOpts...),
+		stubbed: stubbed,
+	}
+	app.RequireStart()
+	if c, ok := tb.(interface{ Cleanup(func()) }); ok {
+		c.Cleanup(app.RequireStop)
+	}
+	return app
+}
+
+// Stubbed returns the dependencies of the module that were filled with
+// zero values, formatted like "*log.Logger" or `string[name="addr"]`,
+// in sorted order.
+func (app *ModuleApp) Stubbed() []string {
+	return app.stubbed
+}
+
+// missingDeps returns the values that opt consumes but doesn't provide,
+// sorted by name.
+//
+// Values that Fx provides are never missing,
+// and private constructors only provide to their own modules.
+func missingDeps(opt fx.Option) ([]fxdeps.Key, error) {
+	funcs, err := fxbridge.Fx().CollectFuncs(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults := make(map[fxdeps.Key]bool)
+	for _, k := range fxbridge.Fx().DefaultKeys() {
+		defaults[k] = true
+	}
+	consumers := funcs.Consumers
+	for _, p := range funcs.Provides {
+		consumers = append(consumers, fxdeps.Consumer{Func: p.Func, Scope: p.Scope})
+	}
+	provided := func(c fxdeps.Consumer, k fxdeps.Key) bool {
+		if defaults[k] {
+			return true
+		}
+		for _, p := range funcs.Provides {
+			if c.Scope.Sees(p) && containsKey(fxdeps.Outputs(p), k) {
+				return true
+			}
+		}
+		return false
+	}
+
+	reported := make(map[fxdeps.Key]bool)
+	var missing []fxdeps.Key
+	for _, c := range consumers {
+		for _, in := range fxdeps.Inputs(c.Func) {
+			if in.Optional || in.Group != "" || reported[in.Key] || provided(c, in.Key) {
+				continue
+			}
+			reported[in.Key] = true
+			missing = append(missing, in.Key)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].String() < missing[j].String()
+	})
+	return missing, nil
+}
+
+func containsKey(keys []fxdeps.Key, k fxdeps.Key) bool {
+	for _, key := range keys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// stubOption provides the zero value for k.
+func stubOption(k fxdeps.Key) fx.Option {
+	ft := reflect.FuncOf(nil, []reflect.Type{k.Type}, false)
+	fn := reflect.MakeFunc(ft, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.Zero(k.Type)}
+	}).Interface()
+	return fx.Provide(fx.Annotated{Name: k.Name, Target: fn})
+}
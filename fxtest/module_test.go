@@ -0,0 +1,136 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+func TestModule(t *testing.T) {
+	t.Parallel()
+
+	type internal struct{ buf *bytes.Buffer }
+	type params struct {
+		fx.In
+
+		Addr     string       `name:"addr"`
+		Optional *log.Logger  `name:"debug" optional:"true"`
+		Handlers []fx.Printer `group:"handlers"`
+	}
+
+	var (
+		started, stopped bool
+		gotParams        params
+		gotLogger        *log.Logger
+	)
+	mod := fx.Module("server",
+		fx.Provide(
+			func(buf *bytes.Buffer) *internal { return &internal{buf} },
+		),
+		fx.Invoke(func(lc fx.Lifecycle, p params, _ *internal, logger *log.Logger) {
+			gotParams = p
+			gotLogger = logger
+			lc.Append(fx.StartStopHook(
+				func() { started = true },
+				func() { stopped = true },
+			))
+		}),
+	)
+	fake := log.New(new(bytes.Buffer), "", 0)
+
+	t.Run("run", func(t *testing.T) {
+		app := Module(t, mod, fx.Supply(fake))
+		assert.Equal(t, []string{"*bytes.Buffer", `string[name="addr"]`}, app.Stubbed())
+		assert.True(t, started, "OnStart hook must run")
+		assert.False(t, stopped, "OnStop hook must not run until the test ends")
+	})
+
+	assert.True(t, stopped, "OnStop hook must run when the test ends")
+	assert.Same(t, fake, gotLogger, "fakes must be used")
+	assert.Equal(t, "", gotParams.Addr)
+	assert.Nil(t, gotParams.Optional)
+	assert.Empty(t, gotParams.Handlers)
+}
+
+func TestModuleNoStubs(t *testing.T) {
+	t.Parallel()
+
+	app := Module(t, fx.Module("empty", fx.Invoke(func(fx.Shutdowner) {})))
+	require.NotNil(t, app)
+	assert.Empty(t, app.Stubbed())
+}
+
+func TestModuleFailure(t *testing.T) {
+	t.Parallel()
+
+	spy := newTB()
+	app := Module(spy, fx.Module("bad",
+		// Only valid for top-level applications.
+		fx.ConstructTimeout(time.Second),
+	))
+
+	assert.Nil(t, app)
+	assert.Equal(t, 1, spy.failures)
+	assert.Contains(t, spy.errors.String(), "fxtest.Module failed")
+}
+
+func TestModulePrivate(t *testing.T) {
+	t.Parallel()
+
+	type secret struct{ name string }
+
+	var inside, outside *secret
+	mod := fx.Module("vault",
+		fx.Provide(
+			fx.Private,
+			func() *secret { return &secret{name: "inside"} },
+		),
+		fx.Invoke(func(s *secret) { inside = s }),
+	)
+	app := Module(t, mod, fx.Invoke(func(s *secret) { outside = s }))
+
+	assert.Equal(t, []string{"*fxtest.secret"}, app.Stubbed())
+	assert.Equal(t, &secret{name: "inside"}, inside)
+	assert.Nil(t, outside, "private values must not be visible outside the module")
+}
+
+func TestModuleFxTypes(t *testing.T) {
+	t.Parallel()
+
+	app := Module(t, fx.Module("fx types",
+		fx.Invoke(func(
+			fx.Lifecycle,
+			fx.Shutdowner,
+			fx.DotGraph,
+			fx.AppContext,
+			fx.ConstructContext,
+		) {
+		}),
+	), fx.ConstructTimeout(time.Minute))
+	assert.Empty(t, app.Stubbed())
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/fx/internal/fxdeps"
+)
+
+// Override is an option for [New] that replaces values in the container
+// like [fx.Replace], and makes [App.RequireStop] fail the test if any of
+// the values was never injected.
+// This catches overrides that silently do nothing,
+// e.g. because they replace the wrong type.
+//
+//	app := fxtest.New(t,
+//		server.Module,
+//		fxtest.Override(fakeClock),
+//	)
+//	defer app.RequireStart().RequireStop()
+//
+// A value counts as injected if a constructor or decorator was called
+// with it, or if an invoked function consumes it.
+// Like [fx.Replace], an Override given to an [fx.Module] only applies
+// to that module and its descendants,
+// so only their functions count.
+// RequireStop logs the functions that received each value.
+//
+// As with [fx.Replace], the most specific type of each value is used,
+// unless the value is annotated with [fx.As] or [fx.ResultTags]:
+//
+//	fxtest.Override(fx.Annotate(fakeStore, fx.As(new(Store))))
+//
+// Override may be nested in [fx.Options] or [fx.Module],
+// but the application must be built with [New]:
+// [fx.New] fails with an error otherwise.
+func Override(values ...interface{}) fx.Option {
+	keys, err := fxbridge.Fx().ReplacedKeys(values)
+	if err != nil {
+		err = fmt.Errorf("fxtest.Override: %w", err)
+	}
+	o := override{keys: keys, err: err}
+
+	opt := testOption("fxtest.Override", o)
+	if o.err == nil {
+		opt = fx.Options(fx.Replace(values...), opt)
+	}
+	return overrideOption{
+		Option: opt,
+		values: values,
+		keys:   keys,
+	}
+}
+
+type overrideOption struct {
+	fx.Option // replaces the values and tells New about them
+
+	values []interface{}
+	keys   []fxdeps.Key // nil if the values can't be overridden
+}
+
+// override is passed to App.applyOption by Override.
+type override struct {
+	keys []fxdeps.Key // values replaced in the container
+	err  error        // set if the values can't be overridden
+}
+
+func (o overrideOption) String() string {
+	var items []string
+	if o.keys != nil {
+		for _, k := range o.keys {
+			items = append(items, k.String())
+		}
+	} else {
+		for _, v := range o.values {
+			items = append(items, fmt.Sprint(reflect.TypeOf(v)))
+		}
+	}
+	return fmt.Sprintf("fxtest.Override(%v)", strings.Join(items, ", "))
+}
+
+// overridden is a value replaced by Override.
+type overridden struct {
+	key   fxdeps.Key
+	scope interface{} // module that the value was replaced in
+}
+
+// override makes RequireStop verify that the values of o are injected
+// in scope, or records why they can't be overridden.
+func (app *App) override(o override, scope interface{}) {
+	if o.err != nil {
+		app.optionErrs = append(app.optionErrs, o.err)
+		return
+	}
+	for _, k := range o.keys {
+		app.overrides = append(app.overrides, overridden{key: k, scope: scope})
+	}
+}
+
+// requireOverridesUsed fails the test if any value passed to Override
+// wasn't injected, and logs the functions that received the others.
+func (app *App) requireOverridesUsed() {
+	var failed bool
+	for _, o := range app.overrides {
+		consumers := fxbridge.Fx().GraphConsumers(app.App, o.key, o.scope)
+		if len(consumers) == 0 {
+			app.tb.Errorf("fxtest.Override(%v) was never injected: "+
+				"no constructor or decorator was called with it and no invoked function consumes it", o.key)
+			failed = true
+			continue
+		}
+
+		names := make([]string, len(consumers))
+		for i, c := range consumers {
+			names[i] = fmt.Sprintf("%v %v", c.Kind, c.Name)
+			if c.ModuleName != "" {
+				names[i] += fmt.Sprintf(" from module %q", c.ModuleName)
+			}
+		}
+		app.tb.Logf("fxtest.Override(%v) was injected into:\n\t%v", o.key, strings.Join(names, "\n\t"))
+	}
+	if failed {
+		app.tb.FailNow()
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+)
+
+type (
+	overrideConfig struct{ Addr string }
+	overrideServer struct{ Addr string }
+)
+
+func newOverrideConfig() *overrideConfig { return &overrideConfig{Addr: "real"} }
+
+func newOverrideServer(cfg *overrideConfig) *overrideServer {
+	return &overrideServer{Addr: cfg.Addr}
+}
+
+func TestOverride(t *testing.T) {
+	t.Parallel()
+
+	t.Run("injected into constructor", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		var s *overrideServer
+		New(spy,
+			fx.Provide(newOverrideConfig),
+			fx.Module("server",
+				fx.Provide(newOverrideServer),
+				fx.Populate(&s),
+			),
+			Override(&overrideConfig{Addr: "fake"}),
+		).RequireStart().RequireStop()
+
+		assert.Zero(t, spy.failures, spy.errors.String())
+		assert.Equal(t, "fake", s.Addr)
+		assert.Contains(t, spy.logs.String(), "fxtest.Override(*fxtest.overrideConfig) was injected into:\n"+
+			"\tconstructor go.uber.org/fx/fxtest.newOverrideServer() from module \"server\"")
+	})
+
+	t.Run("injected into invoke", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy,
+			fx.Provide(newOverrideConfig),
+			fx.Invoke(func(*overrideConfig) {}),
+			Override(&overrideConfig{Addr: "fake"}),
+		).RequireStart().RequireStop()
+
+		assert.Zero(t, spy.failures, spy.errors.String())
+		assert.Contains(t, spy.logs.String(), "\tinvoke go.uber.org/fx/fxtest.TestOverride.func2.1()")
+	})
+
+	t.Run("constructor not called", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy,
+			fx.Provide(newOverrideConfig, newOverrideServer),
+			Override(&overrideConfig{Addr: "fake"}),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "fxtest.Override(*fxtest.overrideConfig) was never injected")
+	})
+
+	t.Run("wrong type", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy,
+			fx.Provide(newOverrideConfig, newOverrideServer),
+			fx.Invoke(func(*overrideServer) {}),
+			// Overrides overrideConfig, not *overrideConfig.
+			Override(overrideConfig{Addr: "fake"}),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "fxtest.Override(fxtest.overrideConfig) was never injected")
+	})
+
+	t.Run("injected into decorator", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		var s *overrideServer
+		New(spy,
+			fx.Provide(newOverrideConfig, func() *overrideServer { return &overrideServer{} }),
+			fx.Decorate(func(s *overrideServer, cfg *overrideConfig) *overrideServer {
+				return &overrideServer{Addr: cfg.Addr}
+			}),
+			fx.Populate(&s),
+			Override(&overrideConfig{Addr: "fake"}),
+		).RequireStart().RequireStop()
+
+		assert.Zero(t, spy.failures, spy.errors.String())
+		assert.Equal(t, "fake", s.Addr)
+		assert.Contains(t, spy.logs.String(), "fxtest.Override(*fxtest.overrideConfig) was injected into:\n"+
+			"\tdecorator go.uber.org/fx/fxtest.TestOverride.func5.2()\n")
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy,
+			fx.Provide(newOverrideConfig),
+			fx.Module("server",
+				fx.Provide(newOverrideServer),
+				fx.Invoke(func(*overrideServer) {}),
+				fx.Options(
+					Override(&overrideConfig{Addr: "fake"}),
+					Override("unused"),
+				),
+			),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.logs.String(), "fxtest.Override(*fxtest.overrideConfig) was injected into:\n"+
+			"\tconstructor go.uber.org/fx/fxtest.newOverrideServer() from module \"server\"")
+		assert.Contains(t, spy.errors.String(), "fxtest.Override(string) was never injected")
+	})
+
+	t.Run("nested and consumed from the root", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		var cfg *overrideConfig
+		New(spy,
+			fx.Provide(newOverrideConfig),
+			fx.Module("m", Override(&overrideConfig{Addr: "fake"})),
+			fx.Invoke(func(c *overrideConfig) { cfg = c }),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, "real", cfg.Addr)
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "fxtest.Override(*fxtest.overrideConfig) was never injected")
+	})
+
+	t.Run("without fxtest.New", func(t *testing.T) {
+		t.Parallel()
+
+		app := fx.New(fx.NopLogger, fx.Options(Override(&overrideConfig{})))
+		assert.ErrorContains(t, app.Err(),
+			"fxtest.Override Option should be passed to fxtest.New, not to fx.New")
+	})
+
+	t.Run("annotated with As", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		var (
+			fake bytes.Buffer
+			got  io.Writer
+		)
+		New(spy,
+			fx.Provide(func() io.Writer { return io.Discard }),
+			fx.Invoke(func(w io.Writer) { got = w }),
+			Override(fx.Annotate(&fake, fx.As(new(io.Writer)))),
+		).RequireStart().RequireStop()
+
+		assert.Zero(t, spy.failures, spy.errors.String())
+		assert.Same(t, &fake, got)
+		assert.Contains(t, spy.logs.String(), "fxtest.Override(io.Writer) was injected into:\n")
+	})
+
+	t.Run("annotated with ResultTags", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy,
+			fx.Provide(
+				fx.Annotate(newOverrideConfig, fx.ResultTags(`name:"cfg"`)),
+				newOverrideConfig,
+			),
+			fx.Invoke(func(*overrideConfig) {}),
+			Override(fx.Annotate(&overrideConfig{Addr: "fake"}, fx.ResultTags(`name:"cfg"`))),
+		).RequireStart().RequireStop()
+
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(),
+			`fxtest.Override(*fxtest.overrideConfig[name="cfg"]) was never injected`)
+	})
+
+	t.Run("annotation error", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy, Override(fx.Annotate(&overrideConfig{}, fx.ResultTags(`name:"a"`), fx.ResultTags(`name:"b"`))))
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "fxtest.Override: encountered error while applying annotation "+
+			"using fx.Annotate to *fxtest.overrideConfig: cannot apply more than one line of ResultTags")
+	})
+
+	t.Run("fx type", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy,
+			fx.Supply(fx.Hook{}),
+			fx.Invoke(func(fx.Hook) {}),
+			Override(fx.Hook{}),
+		).RequireStart().RequireStop()
+
+		assert.Zero(t, spy.failures, spy.errors.String())
+	})
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t,
+			"fxtest.Override(*fxtest.overrideConfig, string)",
+			Override(&overrideConfig{}, "x").String())
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"sync"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/internal/fxbridge"
+)
+
+// ShutdownRecorder records calls to [fx.Shutdowner.Shutdown]
+// instead of shutting down the application.
+// Use it to test components that request a shutdown,
+// e.g. with a specific exit code or reason.
+//
+//	rec := fxtest.NewShutdownRecorder()
+//	app := fxtest.New(t, rec.Option(), worker.Module)
+//	app.RequireStart()
+//	// ...
+//	sigs := rec.Signals()
+//	require.Len(t, sigs, 1)
+//	assert.Equal(t, 2, sigs[0].ExitCode)
+type ShutdownRecorder struct {
+	mu      sync.Mutex
+	signals []fx.ShutdownSignal
+}
+
+// NewShutdownRecorder builds a new ShutdownRecorder.
+func NewShutdownRecorder() *ShutdownRecorder {
+	return &ShutdownRecorder{}
+}
+
+// Option is an [fx.Option] that makes the application's [fx.Shutdowner]
+// record the signal each call to Shutdown would send, instead of sending it.
+// Shutdown then always succeeds, and channels returned by
+// [fx.App.Done] and [fx.App.Wait] don't receive anything.
+//
+// It must be passed to the top-level application, not to an [fx.Module].
+func (r *ShutdownRecorder) Option() fx.Option {
+	return fxbridge.Fx().RecordShutdowns(func(sig interface{}) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.signals = append(r.signals, sig.(fx.ShutdownSignal))
+	}).(fx.Option)
+}
+
+// Signals returns the signals recorded so far, in the order Shutdown was called.
+func (r *ShutdownRecorder) Signals() []fx.ShutdownSignal {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]fx.ShutdownSignal(nil), r.signals...)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+func TestShutdownRecorder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("records without shutting down", func(t *testing.T) {
+		t.Parallel()
+
+		rec := NewShutdownRecorder()
+		var s fx.Shutdowner
+		app := New(t, rec.Option(), fx.Populate(&s)).RequireStart()
+		defer app.RequireStop()
+
+		reason := errors.New("great sadness")
+		require.NoError(t, s.Shutdown(fx.ExitCode(2), fx.ShutdownReason(reason)))
+		require.NoError(t, s.Shutdown(fx.ShutdownError(reason)))
+		app.RequireNoShutdown(10 * time.Millisecond)
+
+		sigs := rec.Signals()
+		require.Len(t, sigs, 2)
+		assert.Equal(t, 2, sigs[0].ExitCode)
+		assert.Same(t, reason, sigs[0].Reason)
+		assert.Equal(t, 2, sigs[1].ExitCode, "exit code is kept across calls")
+		assert.Same(t, reason, sigs[1].Reason)
+		assert.NoError(t, app.Err(), "recorded errors don't fail the application")
+	})
+
+	t.Run("from a module", func(t *testing.T) {
+		t.Parallel()
+
+		rec := NewShutdownRecorder()
+		app := New(t,
+			rec.Option(),
+			fx.Module("worker",
+				fx.Invoke(func(s fx.Shutdowner) error {
+					return s.Shutdown(fx.ExitCode(4))
+				}),
+			),
+		)
+		defer app.RequireStart().RequireStop()
+
+		sigs := rec.Signals()
+		require.Len(t, sigs, 1)
+		assert.Equal(t, 4, sigs[0].ExitCode)
+	})
+
+	t.Run("not top-level", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		New(spy, fx.Module("worker", NewShutdownRecorder().Option()))
+		assert.Equal(t, 1, spy.failures)
+		assert.Contains(t, spy.errors.String(), "should be passed to top-level App")
+	})
+}
@@ -0,0 +1,325 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+// EventSpy records the events logged by an Fx application
+// so that tests can make assertions about them.
+//
+//	spy := fxtest.NewEventSpy(t)
+//	app := fxtest.New(t, spy.Option(), ...)
+//	app.RequireStart().RequireStop()
+//	spy.RequireInvokedInOrder("registerRoutes", "startServer")
+//
+// Query methods report what happened, and methods prefixed with Require
+// fail the test with a diff of the expected and actual events
+// if it didn't happen as expected.
+type EventSpy struct {
+	tb TB
+
+	mu     sync.Mutex
+	events []fxevent.Event
+}
+
+var _ fxevent.Logger = (*EventSpy)(nil)
+
+// NewEventSpy builds a new EventSpy that reports failures to tb.
+func NewEventSpy(tb TB) *EventSpy {
+	return &EventSpy{tb: tb}
+}
+
+// Option returns an [fx.Option] that makes the application log events
+// to the spy, and to tb like [WithTestLogger].
+func (s *EventSpy) Option() fx.Option {
+	return fx.WithLogger(func() fxevent.Logger {
+		return fxevent.Tee(s, NewTestLogger(s.tb))
+	})
+}
+
+// LogEvent records the event.
+func (s *EventSpy) LogEvent(event fxevent.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+// Reset forgets all recorded events.
+func (s *EventSpy) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = nil
+}
+
+// Events returns the recorded events in the order they were logged.
+func (s *EventSpy) Events() []fxevent.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]fxevent.Event, len(s.events))
+	copy(events, s.events)
+	return events
+}
+
+// EventTypes returns the names of the types of the recorded events,
+// such as "Provided" or "OnStartExecuted".
+func (s *EventSpy) EventTypes() []string {
+	events := s.Events()
+	types := make([]string, len(events))
+	for i, e := range events {
+		types[i] = eventType(e)
+	}
+	return types
+}
+
+// EventsOfType returns the recorded events whose type has the given name,
+// such as "Provided".
+func (s *EventSpy) EventsOfType(name string) []fxevent.Event {
+	var out []fxevent.Event
+	for _, e := range s.Events() {
+		if eventType(e) == name {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Provided returns the event for the constructor that provided the type
+// with the given name, such as "*http.Server" or `string[name = "addr"]`,
+// or nil if it wasn't provided.
+func (s *EventSpy) Provided(typeName string) *fxevent.Provided {
+	for _, e := range s.Events() {
+		p, ok := e.(*fxevent.Provided)
+		if !ok {
+			continue
+		}
+		for _, name := range p.OutputTypeNames {
+			if name == typeName {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// Invoked returns the names of the functions that were invoked with
+// [fx.Invoke], in the order they were invoked.
+func (s *EventSpy) Invoked() []string {
+	var names []string
+	for _, e := range s.EventsOfType("Invoked") {
+		names = append(names, e.(*fxevent.Invoked).FunctionName)
+	}
+	return names
+}
+
+// InvokedInOrder reports whether functions matching the given names were
+// invoked in that order, possibly with other functions invoked in between.
+// A name matches a function if it's part of its fully qualified name,
+// so "registerRoutes" matches "main.registerRoutes()".
+func (s *EventSpy) InvokedInOrder(names ...string) bool {
+	return inOrder(s.Invoked(), names)
+}
+
+// HookRun is a lifecycle hook that ran.
+type HookRun struct {
+	Method       string // "OnStart" or "OnStop"
+	FunctionName string
+	CallerName   string
+	Runtime      time.Duration
+	Err          error
+}
+
+// hookRunOf returns the hook run that e reports,
+// or false if e isn't an OnStartExecuted or OnStopExecuted event.
+func hookRunOf(e fxevent.Event) (HookRun, bool) {
+	switch e := e.(type) {
+	case *fxevent.OnStartExecuted:
+		return HookRun{
+			Method:       "OnStart",
+			FunctionName: e.FunctionName,
+			CallerName:   e.CallerName,
+			Runtime:      e.Runtime,
+			Err:          e.Err,
+		}, true
+	case *fxevent.OnStopExecuted:
+		return HookRun{
+			Method:       "OnStop",
+			FunctionName: e.FunctionName,
+			CallerName:   e.CallerName,
+			Runtime:      e.Runtime,
+			Err:          e.Err,
+		}, true
+	default:
+		return HookRun{}, false
+	}
+}
+
+// HooksRun returns the OnStart and OnStop hooks that ran,
+// in the order they finished.
+func (s *EventSpy) HooksRun() []HookRun {
+	var hooks []HookRun
+	for _, e := range s.Events() {
+		if run, ok := hookRunOf(e); ok {
+			hooks = append(hooks, run)
+		}
+	}
+	return hooks
+}
+
+// RequireEventTypes fails the test if the types of the recorded events
+// aren't exactly the given types, in order.
+func (s *EventSpy) RequireEventTypes(want ...string) {
+	got := s.EventTypes()
+	if !reflect.DeepEqual(want, got) {
+		s.fail("event types don't match", want, got)
+	}
+}
+
+// RequireProvided fails the test if no constructor provided the type
+// with the given name, listing the types that were provided.
+func (s *EventSpy) RequireProvided(typeName string) *fxevent.Provided {
+	p := s.Provided(typeName)
+	if p == nil {
+		var provided []string
+		for _, e := range s.EventsOfType("Provided") {
+			provided = append(provided, e.(*fxevent.Provided).OutputTypeNames...)
+		}
+		s.tb.Errorf("%v was not provided; provided types:%v", typeName, indentLines(provided))
+		s.tb.FailNow()
+	}
+	return p
+}
+
+// RequireInvokedInOrder fails the test unless functions matching the
+// given names were invoked in that order. See [EventSpy.InvokedInOrder].
+// The failure reports the first name that had no match.
+func (s *EventSpy) RequireInvokedInOrder(names ...string) {
+	got := s.Invoked()
+	matched := matchInOrder(got, names, strings.Contains)
+	if len(matched) < len(names) {
+		s.tb.Errorf("functions weren't invoked in order:\n%v", describeOrder(names, got, matched))
+		s.tb.FailNow()
+	}
+}
+
+func (s *EventSpy) fail(msg string, want, got []string) {
+	s.tb.Errorf("%v:\n%v", msg, diffLines(want, got))
+	s.tb.FailNow()
+}
+
+func eventType(e fxevent.Event) string {
+	return reflect.TypeOf(e).Elem().Name()
+}
+
+// inOrder reports whether each of names is part of an item of items,
+// in the same order.
+func inOrder(items, names []string) bool {
+	return len(matchInOrder(items, names, strings.Contains)) == len(names)
+}
+
+// matchInOrder matches each of patterns against items, in order,
+// and returns the indexes of the items that they matched.
+// It stops at the first pattern that doesn't match any item
+// after the one that the previous pattern matched.
+func matchInOrder[T any](items []T, patterns []string, match func(T, string) bool) []int {
+	var matched []int
+	for i, item := range items {
+		if len(matched) < len(patterns) && match(item, patterns[len(matched)]) {
+			matched = append(matched, i)
+		}
+	}
+	return matched
+}
+
+// describeOrder explains why patterns didn't match names in order,
+// given the indexes of the names that the first patterns matched.
+func describeOrder(patterns, names []string, matched []int) string {
+	var b strings.Builder
+	for i, idx := range matched {
+		fmt.Fprintf(&b, "%q matched %v\n", patterns[i], names[idx])
+	}
+	missing := patterns[len(matched)]
+	if len(matched) > 0 {
+		fmt.Fprintf(&b, "%q has no match after it\n", missing)
+	} else {
+		fmt.Fprintf(&b, "%q has no match\n", missing)
+	}
+	fmt.Fprintf(&b, "got:%v", indentLines(names))
+	return b.String()
+}
+
+// indentLines formats lines one per line, each starting with a tab,
+// or " none" if there are no lines.
+func indentLines(lines []string) string {
+	if len(lines) == 0 {
+		return " none"
+	}
+	return "\n\t" + strings.Join(lines, "\n\t")
+}
+
+// diffLines returns a line-by-line diff of want and got.
+// Lines only in want are prefixed with "-",
+// and lines only in got are prefixed with "+".
+func diffLines(want, got []string) string {
+	// lcs[i][j] is the length of the longest common subsequence
+	// of want[i:] and got[j:].
+	lcs := make([][]int, len(want)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(got)+1)
+	}
+	for i := len(want) - 1; i >= 0; i-- {
+		for j := len(got) - 1; j >= 0; j-- {
+			if want[i] == got[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("--- want\n+++ got\n")
+	i, j := 0, 0
+	for i < len(want) || j < len(got) {
+		switch {
+		case i < len(want) && j < len(got) && want[i] == got[j]:
+			fmt.Fprintf(&b, "  %v\n", want[i])
+			i++
+			j++
+		case j < len(got) && (i == len(want) || lcs[i][j+1] >= lcs[i+1][j]):
+			fmt.Fprintf(&b, "+ %v\n", got[j])
+			j++
+		default:
+			fmt.Fprintf(&b, "- %v\n", want[i])
+			i++
+		}
+	}
+	return b.String()
+}
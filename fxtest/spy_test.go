@@ -0,0 +1,195 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxtest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+)
+
+func registerRoutes() {}
+func startServer()    {}
+
+func TestEventSpy(t *testing.T) {
+	t.Parallel()
+
+	newApp := func(t *testing.T) *EventSpy {
+		spy := NewEventSpy(t)
+		New(t,
+			spy.Option(),
+			fx.Provide(func() *bytes.Buffer { return new(bytes.Buffer) }),
+			fx.Invoke(registerRoutes),
+			fx.Invoke(func(lc fx.Lifecycle, _ *bytes.Buffer) {
+				lc.Append(fx.StartStopHook(
+					func() {},
+					func() error { return errors.New("great sadness") },
+				))
+			}),
+			fx.Invoke(startServer),
+		).RequireStart().Stop(context.Background())
+		return spy
+	}
+
+	t.Run("queries", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newApp(t)
+
+		p := spy.Provided("*bytes.Buffer")
+		require.NotNil(t, p)
+		assert.Contains(t, p.ConstructorName, "TestEventSpy")
+		assert.Nil(t, spy.Provided("string"))
+
+		assert.Len(t, spy.Invoked(), 3)
+		assert.True(t, spy.InvokedInOrder("registerRoutes", "startServer"))
+		assert.False(t, spy.InvokedInOrder("startServer", "registerRoutes"))
+
+		hooks := spy.HooksRun()
+		require.Len(t, hooks, 2)
+		assert.Equal(t, "OnStart", hooks[0].Method)
+		assert.NoError(t, hooks[0].Err)
+		assert.Equal(t, "OnStop", hooks[1].Method)
+		assert.EqualError(t, hooks[1].Err, "great sadness")
+
+		assert.Len(t, spy.EventsOfType("Invoking"), 3)
+		assert.Equal(t, len(spy.Events()), len(spy.EventTypes()))
+
+		spy.Reset()
+		assert.Empty(t, spy.Events())
+	})
+
+	t.Run("passing assertions", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newApp(t)
+		spy.RequireProvided("*bytes.Buffer")
+		spy.RequireInvokedInOrder("registerRoutes", "startServer")
+		spy.RequireEventTypes(spy.EventTypes()...)
+	})
+
+	t.Run("failing assertions", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newApp(t)
+		fake := newTB()
+		spy.tb = fake
+
+		spy.RequireInvokedInOrder("startServer", "registerRoutes")
+		assert.Equal(t, 1, fake.failures)
+		assert.Contains(t, fake.errors.String(), "functions weren't invoked in order:\n"+
+			`"startServer" matched go.uber.org/fx/fxtest.startServer()`+"\n"+
+			`"registerRoutes" has no match after it`+"\n"+
+			"got:\n\tgo.uber.org/fx/fxtest.registerRoutes()\n")
+
+		spy.RequireProvided("string")
+		assert.Equal(t, 2, fake.failures)
+		assert.Contains(t, fake.errors.String(), "string was not provided; provided types:\n\t")
+		assert.Contains(t, fake.errors.String(), "\t*bytes.Buffer")
+	})
+}
+
+func TestDescribeOrder(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"main.a()", "main.b()", "main.c()"}
+
+	t.Run("first pattern missing", func(t *testing.T) {
+		t.Parallel()
+
+		patterns := []string{"d", "a"}
+		matched := matchInOrder(names, patterns, strings.Contains)
+		assert.Empty(t, matched)
+		assert.Equal(t,
+			`"d" has no match`+"\ngot:\n\tmain.a()\n\tmain.b()\n\tmain.c()",
+			describeOrder(patterns, names, matched))
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		t.Parallel()
+
+		patterns := []string{"a", "c", "b"}
+		matched := matchInOrder(names, patterns, strings.Contains)
+		assert.Equal(t, []int{0, 2}, matched)
+		assert.Equal(t,
+			`"a" matched main.a()`+"\n"+
+				`"c" matched main.c()`+"\n"+
+				`"b" has no match after it`+"\ngot:\n\tmain.a()\n\tmain.b()\n\tmain.c()",
+			describeOrder(patterns, names, matched))
+	})
+}
+
+func TestDiffLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc string
+		want []string
+		got  []string
+		diff string
+	}{
+		{
+			desc: "equal",
+			want: []string{"a", "b"},
+			got:  []string{"a", "b"},
+			diff: "  a\n  b\n",
+		},
+		{
+			desc: "missing",
+			want: []string{"a", "b", "c"},
+			got:  []string{"a", "c"},
+			diff: "  a\n- b\n  c\n",
+		},
+		{
+			desc: "extra",
+			want: []string{"a", "c"},
+			got:  []string{"a", "b", "c"},
+			diff: "  a\n+ b\n  c\n",
+		},
+		{
+			desc: "changed",
+			want: []string{"Provided", "Started"},
+			got:  []string{"Provided", "RollingBack", "RolledBack", "Started"},
+			diff: "  Provided\n+ RollingBack\n+ RolledBack\n  Started\n",
+		},
+		{
+			desc: "empty",
+			want: []string{"a"},
+			got:  nil,
+			diff: "- a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, "--- want\n+++ got\n"+tt.diff, diffLines(tt.want, tt.got))
+		})
+	}
+}
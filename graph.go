@@ -0,0 +1,378 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.uber.org/fx/internal/fxbridge"
+	"go.uber.org/fx/internal/fxdeps"
+	"go.uber.org/fx/internal/fxreflect"
+)
+
+// _defaultProvider names the provider of values that Fx provides
+// to every application in graph snapshots.
+const _defaultProvider = "fx"
+
+// graphSnapshot describes the modules, constructors, decorators, and
+// invoked functions of the application, and which of them consume the
+// values produced by which others.
+//
+// Edges follow Dig's scoping: a function gets each value from the
+// nearest decorator or fx.Replace for it in its module or an ancestor,
+// or else from the constructors visible to its module.
+//
+// Everything is sorted, and source locations are left out,
+// so that the snapshot only changes when the wiring does.
+// Values that Fx provides to every application are attributed to "fx".
+func (app *App) graphSnapshot() string {
+	var sb strings.Builder
+
+	sb.WriteString("modules:\n")
+	sb.WriteString("  (root)\n")
+	writeModuleTree(&sb, app.root, 2)
+
+	var constructors, decorators, invokes []*snapshotFunc
+	for _, c := range app.constructors {
+		if c.Default {
+			continue
+		}
+		constructors = append(constructors, &snapshotFunc{
+			kind:    "constructor",
+			name:    c.Name,
+			module:  c.Module,
+			private: c.Private,
+			inputs:  c.InputKeys,
+			outputs: c.OutputKeys,
+		})
+	}
+	var walk func(*module)
+	walk = func(m *module) {
+		for _, d := range m.decorators {
+			f := &snapshotFunc{kind: "decorator", module: m}
+			f.inputs, f.outputs = decoratorDeps(d)
+			if d.IsReplace {
+				f.kind = "replace"
+				f.name = fmt.Sprintf("fx.Replace(%v)", d.ReplaceType)
+			} else {
+				f.name = fxreflect.FuncName(d.Target)
+			}
+			decorators = append(decorators, f)
+		}
+		for _, i := range m.invokes {
+			invokes = append(invokes, &snapshotFunc{
+				kind:   "invoke",
+				name:   fxreflect.FuncName(i.Target),
+				module: m,
+				inputs: invokeInputs(i),
+			})
+		}
+		for _, child := range m.modules {
+			walk(child)
+		}
+	}
+	walk(app.root)
+
+	var edges []string
+	for _, section := range []struct {
+		title string
+		funcs []*snapshotFunc
+	}{
+		{"constructors", constructors},
+		{"decorators", decorators},
+		{"invokes", invokes},
+	} {
+		sortSnapshotFuncs(section.funcs)
+		fmt.Fprintf(&sb, "\n%v:\n", section.title)
+		for _, f := range section.funcs {
+			f.write(&sb)
+			for _, in := range f.inputs {
+				for _, src := range app.snapshotSources(decorators, f, in.Key) {
+					edges = append(edges, fmt.Sprintf("  %v -> %v: %v", src, f.name, in.Key))
+				}
+			}
+		}
+	}
+
+	sort.Strings(edges)
+	sb.WriteString("\nedges:\n")
+	for _, e := range dedupe(edges) {
+		sb.WriteString(e)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// snapshotFunc is a function passed to Dig, as shown in a graph snapshot.
+type snapshotFunc struct {
+	kind    string // "constructor", "decorator", "replace", or "invoke"
+	name    string
+	module  *module
+	private bool
+	inputs  []fxdeps.Input
+	outputs []fxdeps.Key
+}
+
+// decorates reports whether f is a decorator or replacement of k.
+func (f *snapshotFunc) decorates(k fxdeps.Key) bool {
+	return (f.kind == "decorator" || f.kind == "replace") && containsKey(f.outputs, k)
+}
+
+func (f *snapshotFunc) write(sb *strings.Builder) {
+	fmt.Fprintf(sb, "  %v", f.name)
+	if f.module.name != "" {
+		fmt.Fprintf(sb, " in module %q", f.module.name)
+	}
+	if f.private {
+		sb.WriteString(" (private)")
+	}
+	sb.WriteString("\n")
+
+	inputs := make([]string, len(f.inputs))
+	for i, in := range f.inputs {
+		inputs[i] = snapshotInput(in)
+	}
+	sort.Strings(inputs)
+	for _, in := range inputs {
+		fmt.Fprintf(sb, "    consumes %v\n", in)
+	}
+	verb := "provides"
+	switch f.kind {
+	case "decorator":
+		verb = "decorates"
+	case "replace":
+		verb = "replaces"
+	}
+	for _, out := range keyStrings(f.outputs) {
+		fmt.Fprintf(sb, "    %v %v\n", verb, out)
+	}
+}
+
+func sortSnapshotFuncs(funcs []*snapshotFunc) {
+	sortKey := func(f *snapshotFunc) string {
+		return f.module.name + "\x00" + f.name + "\x00" + strings.Join(keyStrings(f.outputs), "\x00")
+	}
+	sort.SliceStable(funcs, func(i, j int) bool {
+		return sortKey(funcs[i]) < sortKey(funcs[j])
+	})
+}
+
+// snapshotSources returns the names of the functions that give f
+// the value k, given all decorators of the application.
+//
+// A decorator gets the value it decorates from the scope above its own.
+func (app *App) snapshotSources(decorators []*snapshotFunc, f *snapshotFunc, k fxdeps.Key) []string {
+	scope := f.module
+	if f.decorates(k) {
+		scope = f.module.parent
+	}
+	for m := scope; m != nil; m = m.parent {
+		for _, d := range decorators {
+			if d.module == m && d.decorates(k) {
+				return []string{d.name}
+			}
+		}
+	}
+
+	var names []string
+	for _, c := range app.visibleConstructors(f.module, k) {
+		if c.Default {
+			names = append(names, _defaultProvider)
+		} else {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// graphFuncs lists the constructors, decorators, and invokes of the
+// application, and the hooks appended to its lifecycle.
+func (app *App) graphFuncs() []fxbridge.Func {
+	var funcs []fxbridge.Func
+	for _, c := range app.constructors {
+		if !c.Default {
+			funcs = append(funcs, fxbridge.Func{
+				Kind:       "constructor",
+				Name:       c.Name,
+				ModuleName: c.ModuleName,
+			})
+		}
+	}
+
+	var walk func(*module)
+	walk = func(m *module) {
+		for _, d := range m.decorators {
+			if !d.IsReplace {
+				funcs = append(funcs, fxbridge.Func{
+					Kind:       "decorator",
+					Name:       fxreflect.FuncName(d.Target),
+					ModuleName: m.name,
+				})
+			}
+		}
+		for _, i := range m.invokes {
+			funcs = append(funcs, fxbridge.Func{
+				Kind:       "invoke",
+				Name:       fxreflect.FuncName(i.Target),
+				ModuleName: m.name,
+			})
+		}
+		for _, child := range m.modules {
+			walk(child)
+		}
+	}
+	walk(app.root)
+
+	for i, h := range app.lifecycle.Hooks() {
+		moduleName := app.hookModuleName(i)
+		if h.OnStartName != "" {
+			funcs = append(funcs, fxbridge.Func{
+				Kind:       "OnStart hook",
+				Name:       h.OnStartName,
+				ModuleName: moduleName,
+			})
+		}
+		if h.OnStopName != "" {
+			funcs = append(funcs, fxbridge.Func{
+				Kind:       "OnStop hook",
+				Name:       h.OnStopName,
+				ModuleName: moduleName,
+			})
+		}
+	}
+	return funcs
+}
+
+// graphConsumers lists the constructors and decorators that were called
+// with the value want, and the invoked functions that consume it,
+// in scope and its descendants.
+//
+// Dig resolves the parameters of a function in the scope it was given to,
+// so functions outside of scope don't see values replaced in it.
+func (app *App) graphConsumers(want fxdeps.Key, scope *module) []fxbridge.Func {
+	var funcs []fxbridge.Func
+	for _, c := range app.constructors {
+		if !c.Ran || !scope.isAncestorOf(c.Module) {
+			continue
+		}
+		for _, in := range c.InputKeys {
+			if in.Key == want {
+				funcs = append(funcs, fxbridge.Func{
+					Kind:       "constructor",
+					Name:       c.Name,
+					ModuleName: c.ModuleName,
+				})
+				break
+			}
+		}
+	}
+
+	var walk func(*module)
+	walk = func(m *module) {
+		for i, d := range m.decorators {
+			if d.IsReplace || i >= len(m.decoratorRan) || !m.decoratorRan[i] {
+				continue
+			}
+			inputs, _ := decoratorDeps(d)
+			for _, in := range inputs {
+				if in.Key == want {
+					funcs = append(funcs, fxbridge.Func{
+						Kind:       "decorator",
+						Name:       fxreflect.FuncName(d.Target),
+						ModuleName: m.name,
+					})
+					break
+				}
+			}
+		}
+		for _, i := range m.invokes {
+			// Find the function passed to Dig, e.g. for fx.Annotate.
+			var c funcCollector
+			if err := runInvoke(&c, i); err != nil || len(c.funcs.Consumers) == 0 {
+				continue
+			}
+			for _, in := range fxdeps.Inputs(c.funcs.Consumers[0].Func) {
+				if in.Key == want {
+					funcs = append(funcs, fxbridge.Func{
+						Kind:       "invoke",
+						Name:       fxreflect.FuncName(i.Target),
+						ModuleName: m.name,
+					})
+					break
+				}
+			}
+		}
+		for _, child := range m.modules {
+			walk(child)
+		}
+	}
+	walk(scope)
+	return funcs
+}
+
+// writeModuleTree writes the names of the descendants of m,
+// indenting each level further.
+func writeModuleTree(sb *strings.Builder, m *module, indent int) {
+	children := make([]*module, len(m.modules))
+	copy(children, m.modules)
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].name < children[j].name
+	})
+	for _, child := range children {
+		fmt.Fprintf(sb, "%v%v\n", strings.Repeat(" ", indent+2), child.name)
+		writeModuleTree(sb, child, indent+2)
+	}
+}
+
+// snapshotInput formats an input of a function for a graph snapshot.
+// Value groups are consumed as slices of the provided type.
+func snapshotInput(in fxdeps.Input) string {
+	if in.Group != "" {
+		return fmt.Sprintf("[]%v[group=%q]", in.Type, in.Group)
+	}
+	if in.Optional {
+		return in.Key.String() + " (optional)"
+	}
+	return in.Key.String()
+}
+
+// keyStrings formats keys, sorted.
+func keyStrings(keys []fxdeps.Key) []string {
+	items := make([]string, len(keys))
+	for i, k := range keys {
+		items[i] = k.String()
+	}
+	sort.Strings(items)
+	return items
+}
+
+// dedupe removes adjacent duplicates from a sorted list.
+func dedupe(items []string) []string {
+	var out []string
+	for i, item := range items {
+		if i == 0 || item != items[i-1] {
+			out = append(out, item)
+		}
+	}
+	return out
+}
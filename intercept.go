@@ -0,0 +1,50 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/fx/internal/fxreflect"
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+// interceptHooksOption adds a function that may replace
+// lifecycle hooks before they run.
+type interceptHooksOption struct{ intercept lifecycle.Interceptor }
+
+func (o interceptHooksOption) apply(m *module) {
+	m.app.hookInterceptors = append(m.app.hookInterceptors, o.intercept)
+}
+
+func (o interceptHooksOption) String() string {
+	return fmt.Sprintf("InterceptHooks(%v)", fxreflect.FuncName(o.intercept))
+}
+
+// interceptHooks applies all interceptors added to the application,
+// in the order they were added.
+func (app *App) interceptHooks(call lifecycle.HookCall, fn func(context.Context) error) func(context.Context) error {
+	for _, intercept := range app.hookInterceptors {
+		fn = intercept(call, fn)
+	}
+	return fn
+}
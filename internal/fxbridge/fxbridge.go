@@ -0,0 +1,126 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package fxbridge gives package fxtest access to parts of package fx
+// that are not part of its public API.
+//
+// Package fx can't be imported from here, so it registers its side of the
+// bridge with [Register] when it's initialized.
+// fx.Option and *fx.App values cross the bridge as interface{}.
+package fxbridge
+
+import (
+	"go.uber.org/fx/internal/fxclock"
+	"go.uber.org/fx/internal/fxdeps"
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+// Funcs are the functions that package fx makes available to fxtest.
+type Funcs struct {
+	// WithClock builds an fx.Option that makes an application
+	// use the given clock.
+	WithClock func(fxclock.Clock) interface{}
+
+	// Clock returns the clock that an *fx.App uses for its timeouts.
+	Clock func(app interface{}) fxclock.Clock
+
+	// InterceptHooks builds an fx.Option that adds an Interceptor
+	// to an application's lifecycle.
+	InterceptHooks func(lifecycle.Interceptor) interface{}
+
+	// RecordShutdowns builds an fx.Option that makes an application's
+	// Shutdowner pass each fx.ShutdownSignal to record
+	// instead of broadcasting it.
+	RecordShutdowns func(record func(signal interface{})) interface{}
+
+	// LifecycleHook converts an fx.Hook into a lifecycle.Hook, keeping the
+	// names of functions wrapped by fx.StartHook and similar.
+	LifecycleHook func(hook interface{}) lifecycle.Hook
+
+	// CollectFuncs returns the functions that the given fx.Option
+	// passes to Dig.
+	CollectFuncs func(opt interface{}) (fxdeps.Funcs, error)
+
+	// DefaultKeys returns the values that Fx provides to applications.
+	DefaultKeys func() []fxdeps.Key
+
+	// GraphSnapshot describes the wiring of an *fx.App
+	// in a stable, diffable form.
+	GraphSnapshot func(app interface{}) string
+
+	// GraphFuncs lists the constructors, decorators, and invokes of an
+	// *fx.App, and the hooks appended to its lifecycle.
+	GraphFuncs func(app interface{}) []Func
+
+	// ReplacedKeys returns the values that fx.Replace replaces
+	// when it's given values, resolving fx.Annotate.
+	ReplacedKeys func(values []interface{}) ([]fxdeps.Key, error)
+
+	// GraphConsumers lists the functions of an *fx.App that were called
+	// with the given value, as seen from scope:
+	// functions of the module that scope identifies and its descendants.
+	// Scopes are passed to the functions given to TestApp.
+	GraphConsumers func(app interface{}, key fxdeps.Key, scope interface{}) []Func
+
+	// TestApp builds an fx.Option for fxtest.New that makes the
+	// application call onOption with the value of each option built by
+	// TestOption, wherever the option is nested, and the scope of
+	// the module that the option was given to.
+	// It must come before all other options.
+	TestApp func(onOption func(value, scope interface{})) interface{}
+
+	// TestOption builds an fx.Option that passes value to the TestApp
+	// of the application when it's applied.
+	// Applications built without TestApp fail with an error
+	// saying that name must be passed to fxtest.New.
+	TestOption func(name string, value interface{}) interface{}
+}
+
+// Func is a function given to an application.
+type Func struct {
+	// One of "constructor", "decorator", "invoke",
+	// "OnStart hook", or "OnStop hook".
+	Kind string
+
+	// Name of the function, as reported in fxevent events.
+	Name string
+
+	// Module that the function was given to, empty for the root.
+	ModuleName string
+}
+
+var _fx *Funcs
+
+// Register makes funcs available through [Fx].
+// It's called once, by package fx.
+func Register(funcs Funcs) {
+	if _fx != nil {
+		panic("fxbridge: Register called twice")
+	}
+	_fx = &funcs
+}
+
+// Fx returns the functions registered by package fx.
+func Fx() *Funcs {
+	if _fx == nil {
+		panic("fxbridge: package fx is not initialized")
+	}
+	return _fx
+}
@@ -110,6 +110,15 @@ func (c *Mock) Sleep(d time.Duration) {
 	<-ch
 }
 
+// After returns a channel that receives the time now + d
+// once the clock is advanced with [Add] past it.
+func (c *Mock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	t := c.Now().Add(d)
+	c.runAt(t, func() { ch <- t })
+	return ch
+}
+
 // WithTimeout returns a new context with a deadline of now + d.
 //
 // When the deadline is passed, the returned context's Done channel is closed
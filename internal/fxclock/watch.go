@@ -0,0 +1,59 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxclock
+
+import (
+	"context"
+	"time"
+)
+
+// Watch starts a watchdog for an operation that should take no longer
+// than threshold. If it does, onSlow is called with how long the operation
+// had been running, as soon as the threshold is exceeded.
+//
+// The returned function must be called when the operation finishes.
+// If the operation finished past the threshold before the watchdog noticed,
+// onSlow is called then instead.
+// Either way, onSlow is called at most once.
+func Watch(c Clock, threshold time.Duration, onSlow func(runtime time.Duration)) (stop func()) {
+	start := c.Now()
+	ctx, cancel := c.WithTimeout(context.Background(), threshold)
+
+	fired := make(chan bool, 1)
+	go func() {
+		<-ctx.Done()
+		slow := ctx.Err() == context.DeadlineExceeded
+		if slow {
+			onSlow(c.Since(start))
+		}
+		fired <- slow
+	}()
+
+	return func() {
+		cancel()
+		if <-fired {
+			return
+		}
+		if runtime := c.Since(start); runtime > threshold {
+			onSlow(runtime)
+		}
+	}
+}
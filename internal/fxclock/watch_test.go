@@ -0,0 +1,71 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxclock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fast", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewMock()
+		stop := Watch(clock, time.Second, func(time.Duration) {
+			assert.Fail(t, "must not be called")
+		})
+		clock.Add(time.Millisecond)
+		stop()
+	})
+
+	t.Run("slow while running", func(t *testing.T) {
+		t.Parallel()
+
+		clock := NewMock()
+		slow := make(chan time.Duration, 2)
+		stop := Watch(clock, time.Second, func(d time.Duration) { slow <- d })
+
+		clock.AwaitScheduled(1)
+		clock.Add(time.Second)
+		assert.Equal(t, time.Second, <-slow, "must fire before the operation finishes")
+
+		clock.Add(time.Second)
+		stop()
+		assert.Empty(t, slow, "must fire only once")
+	})
+
+	t.Run("system clock", func(t *testing.T) {
+		t.Parallel()
+
+		slow := make(chan time.Duration, 2)
+		stop := Watch(System, time.Millisecond, func(d time.Duration) { slow <- d })
+		time.Sleep(10 * time.Millisecond)
+		stop()
+
+		assert.GreaterOrEqual(t, <-slow, time.Millisecond)
+		assert.Empty(t, slow, "must fire only once")
+	})
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package fxdeps finds the values that a set of Fx options
+// consumes from and produces into the container.
+//
+// Dig only exposes the types of its inputs and outputs as strings,
+// so this inspects the functions passed to Dig instead.
+package fxdeps
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/dig"
+)
+
+// Key identifies a value in the container.
+type Key struct {
+	Type  reflect.Type
+	Name  string
+	Group string
+}
+
+// String formats the key the same way Dig does.
+func (k Key) String() string {
+	switch {
+	case k.Name != "":
+		return fmt.Sprintf("%v[name=%q]", k.Type, k.Name)
+	case k.Group != "":
+		return fmt.Sprintf("%v[group=%q]", k.Type, k.Group)
+	default:
+		return k.Type.String()
+	}
+}
+
+// Input is a value consumed by a function.
+type Input struct {
+	Key
+
+	Optional bool
+}
+
+// Funcs are the functions that a set of options passes to Dig,
+// in the form Dig receives them.
+type Funcs struct {
+	// Constructors, including those for supplied values.
+	Provides []Provide
+
+	// Invoked functions and decorators.
+	// Only their inputs matter.
+	Consumers []Consumer
+}
+
+// Provide is a constructor passed to Dig.
+type Provide struct {
+	Func interface{}
+
+	// Name or group passed to Dig along with Func, if any.
+	Name, Group string
+
+	// Scope is the module that Func was provided to.
+	Scope *Scope
+
+	// Private constructors are only visible to their Scope
+	// and its descendants.
+	Private bool
+}
+
+// Consumer is an invoked function or a decorator passed to Dig.
+type Consumer struct {
+	Func interface{}
+
+	// Scope is the module that Func was given to.
+	Scope *Scope
+}
+
+// Scope is a module that functions are given to.
+// The zero value is a root module.
+type Scope struct {
+	Parent *Scope
+}
+
+// Sees reports whether functions given to s see the values of p.
+func (s *Scope) Sees(p Provide) bool {
+	if !p.Private {
+		return true
+	}
+	for ; s != nil; s = s.Parent {
+		if s == p.Scope {
+			return true
+		}
+	}
+	return false
+}
+
+var _errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Inputs returns the values that fn consumes.
+// It returns nil if fn is not a function.
+func Inputs(fn interface{}) []Input {
+	ft := reflect.TypeOf(fn)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return nil
+	}
+
+	var ins []Input
+	for i := 0; i < ft.NumIn(); i++ {
+		ins = appendInputs(ins, ft.In(i), "")
+	}
+	return ins
+}
+
+// appendInputs appends the values consumed by a parameter of type t
+// with the given struct tag, expanding dig.In structs.
+func appendInputs(ins []Input, t reflect.Type, tag reflect.StructTag) []Input {
+	if dig.IsIn(t) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Anonymous && f.Type == reflect.TypeOf(dig.In{}) || !f.IsExported() {
+				continue
+			}
+			ins = appendInputs(ins, f.Type, f.Tag)
+		}
+		return ins
+	}
+
+	in := Input{
+		Key:      Key{Type: t, Name: tag.Get("name")},
+		Optional: tag.Get("optional") == "true",
+	}
+	if group := tag.Get("group"); group != "" {
+		in.Group, _, _ = strings.Cut(group, ",")
+		in.Type = t.Elem() // value groups are consumed as slices
+	}
+	return append(ins, in)
+}
+
+// Outputs returns the values that the constructor p produces.
+func Outputs(p Provide) []Key {
+	ft := reflect.TypeOf(p.Func)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return nil
+	}
+
+	var outs []Key
+	for i := 0; i < ft.NumOut(); i++ {
+		t := ft.Out(i)
+		if t == _errorType {
+			continue
+		}
+		if dig.IsOut(t) {
+			outs = appendOutputs(outs, t)
+			continue
+		}
+		outs = append(outs, Key{Type: t, Name: p.Name, Group: p.Group})
+	}
+	return outs
+}
+
+// appendOutputs appends the values produced by the dig.Out struct t.
+func appendOutputs(outs []Key, t reflect.Type) []Key {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type == reflect.TypeOf(dig.Out{}) || !f.IsExported() {
+			continue
+		}
+		if dig.IsOut(f.Type) {
+			outs = appendOutputs(outs, f.Type)
+			continue
+		}
+
+		k := Key{Type: f.Type, Name: f.Tag.Get("name")}
+		if group := f.Tag.Get("group"); group != "" {
+			name, opts, _ := strings.Cut(group, ",")
+			k.Group = name
+			if opts == "flatten" {
+				k.Type = f.Type.Elem()
+			}
+		}
+		outs = append(outs, k)
+	}
+	return outs
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fxdeps
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/dig"
+)
+
+func TestInputs(t *testing.T) {
+	t.Parallel()
+
+	type params struct {
+		dig.In
+
+		Buf      *bytes.Buffer `name:"buf"`
+		Reader   io.Reader     `optional:"true"`
+		Writers  []io.Writer   `group:"writers,soft"`
+		internal int
+	}
+
+	ins := Inputs(func(string, params) {})
+	assert.Equal(t, []Input{
+		{Key: Key{Type: reflect.TypeOf("")}},
+		{Key: Key{Type: reflect.TypeOf(&bytes.Buffer{}), Name: "buf"}},
+		{Key: Key{Type: reflect.TypeOf((*io.Reader)(nil)).Elem()}, Optional: true},
+		{Key: Key{Type: reflect.TypeOf((*io.Writer)(nil)).Elem(), Group: "writers"}},
+	}, ins)
+
+	assert.Nil(t, Inputs(42), "not a function")
+}
+
+func TestOutputs(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		dig.Out
+
+		Buf     *bytes.Buffer `name:"buf"`
+		Writers []io.Writer   `group:"writers,flatten"`
+	}
+
+	outs := Outputs(Provide{Func: func() (result, string, error) { panic("not called") }})
+	assert.Equal(t, []Key{
+		{Type: reflect.TypeOf(&bytes.Buffer{}), Name: "buf"},
+		{Type: reflect.TypeOf((*io.Writer)(nil)).Elem(), Group: "writers"},
+		{Type: reflect.TypeOf("")},
+	}, outs)
+
+	outs = Outputs(Provide{Func: func() int { return 0 }, Name: "n"})
+	assert.Equal(t, []Key{{Type: reflect.TypeOf(0), Name: "n"}}, outs)
+	assert.Equal(t, `int[name="n"]`, outs[0].String())
+}
@@ -47,6 +47,7 @@ func (es Events) SelectByTypeName(name string) Events {
 
 // Spy is an Fx event logger that captures emitted events and/or logged
 // statements. It may be used in tests of Fx logs.
+// It's safe for concurrent use.
 type Spy struct {
 	mu     sync.RWMutex
 	events Events
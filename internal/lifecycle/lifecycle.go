@@ -123,6 +123,24 @@ type Hook struct {
 	callerFrame fxreflect.Frame
 }
 
+// onStartName returns the name of the OnStart function,
+// or an empty string if the hook doesn't have one.
+func (h Hook) onStartName() string {
+	if len(h.OnStartName) > 0 || h.OnStart == nil {
+		return h.OnStartName
+	}
+	return fxreflect.FuncName(h.OnStart)
+}
+
+// onStopName returns the name of the OnStop function,
+// or an empty string if the hook doesn't have one.
+func (h Hook) onStopName() string {
+	if len(h.OnStopName) > 0 || h.OnStop == nil {
+		return h.OnStopName
+	}
+	return fxreflect.FuncName(h.OnStop)
+}
+
 type appState int
 
 const (
@@ -161,25 +179,70 @@ type Lifecycle struct {
 	stopRecords  HookRecords
 	runningHook  Hook
 	mu           sync.Mutex
+
+	// If non-zero, a SlowHook event is logged
+	// for each hook that runs longer than this.
+	slowThreshold time.Duration
+
+	// If set, reports the module that appended the hook at an index.
+	moduleOf func(index int) (key interface{}, name string)
+
+	// If set, may replace the function of each hook before it runs.
+	intercept Interceptor
+}
+
+// HookCall describes a hook that's about to run.
+type HookCall struct {
+	Method       string // "OnStart" or "OnStop"
+	FunctionName string
+	ModuleName   string // empty if the hook wasn't appended by a module
 }
 
+// Interceptor is called before each hook runs with the hook's function,
+// and returns the function to run instead.
+type Interceptor func(call HookCall, fn func(context.Context) error) func(context.Context) error
+
 // New constructs a new Lifecycle.
 func New(logger fxevent.Logger, clock fxclock.Clock) *Lifecycle {
 	return &Lifecycle{logger: logger, clock: clock}
 }
 
+// SetSlowThreshold sets how long a hook may run before it's reported with
+// a SlowHook event. Slow hooks are not reported if the threshold is zero.
+func (l *Lifecycle) SetSlowThreshold(d time.Duration) {
+	l.slowThreshold = d
+}
+
+// SetModuleOf sets a function that reports the module that appended the
+// hook at the given index: a comparable key that identifies the module,
+// since module names need not be unique, and the module's name.
+// The key is nil if the hook wasn't appended by a module.
+//
+// If set, ModuleHooksExecuting and ModuleHooksExecuted events are logged
+// around the hooks of each module.
+func (l *Lifecycle) SetModuleOf(moduleOf func(index int) (key interface{}, name string)) {
+	l.moduleOf = moduleOf
+}
+
+// SetInterceptor sets a function that may replace hooks before they run.
+func (l *Lifecycle) SetInterceptor(intercept Interceptor) {
+	l.intercept = intercept
+}
+
 // Append adds a Hook to the lifecycle.
 func (l *Lifecycle) Append(hook Hook) {
 	// Save the caller's stack frame to report file/line number.
 	if f := fxreflect.CallerStack(2, 0); len(f) > 0 {
 		hook.callerFrame = f[0]
 	}
+	l.mu.Lock()
 	l.hooks = append(l.hooks, hook)
+	l.mu.Unlock()
 }
 
 // Start runs all OnStart hooks, returning immediately if it encounters an
 // error.
-func (l *Lifecycle) Start(ctx context.Context) error {
+func (l *Lifecycle) Start(ctx context.Context) (err error) {
 	if ctx == nil {
 		return errors.New("called OnStart with nil context")
 	}
@@ -202,7 +265,16 @@ func (l *Lifecycle) Start(ctx context.Context) error {
 		l.mu.Unlock()
 	}()
 
-	for _, hook := range l.hooks {
+	var order []int
+	for i, hook := range l.hooks {
+		if hook.OnStart != nil {
+			order = append(order, i)
+		}
+	}
+	modules := l.newModuleHooks("OnStart", order)
+	defer func() { modules.finish(err) }()
+
+	for i, hook := range l.hooks {
 		// if ctx has cancelled, bail out of the loop.
 		if err := ctx.Err(); err != nil {
 			return err
@@ -213,31 +285,34 @@ func (l *Lifecycle) Start(ctx context.Context) error {
 			l.runningHook = hook
 			l.mu.Unlock()
 
-			runtime, err := l.runStartHook(ctx, hook)
+			modules.before(i)
+			runtime, err := l.runStartHook(ctx, i, hook)
+			modules.after(i, runtime, err)
 			if err != nil {
 				return err
 			}
 
 			l.mu.Lock()
 			l.startRecords = append(l.startRecords, HookRecord{
+				Index:       i,
 				CallerFrame: hook.callerFrame,
 				Func:        hook.OnStart,
+				Name:        hook.onStartName(),
 				Runtime:     runtime,
 			})
 			l.mu.Unlock()
 		}
+		l.mu.Lock()
 		l.numStarted++
+		l.mu.Unlock()
 	}
 
 	returnState = started
 	return nil
 }
 
-func (l *Lifecycle) runStartHook(ctx context.Context, hook Hook) (runtime time.Duration, err error) {
-	funcName := hook.OnStartName
-	if len(funcName) == 0 {
-		funcName = fxreflect.FuncName(hook.OnStart)
-	}
+func (l *Lifecycle) runStartHook(ctx context.Context, index int, hook Hook) (runtime time.Duration, err error) {
+	funcName := hook.onStartName()
 
 	l.logger.LogEvent(&fxevent.OnStartExecuting{
 		CallerName:   hook.callerFrame.Function,
@@ -252,8 +327,13 @@ func (l *Lifecycle) runStartHook(ctx context.Context, hook Hook) (runtime time.D
 		})
 	}()
 
+	if l.slowThreshold > 0 {
+		defer l.watch(hook, "OnStart", funcName)()
+	}
+
+	fn := l.hookFunc("OnStart", index, funcName, hook.OnStart)
 	begin := l.clock.Now()
-	err = hook.OnStart(ctx)
+	err = fn(ctx)
 	return l.clock.Since(begin), err
 }
 
@@ -285,10 +365,19 @@ func (l *Lifecycle) Stop(ctx context.Context) error {
 	numStarted := l.numStarted
 	l.mu.Unlock()
 
+	var order []int
+	for i := numStarted - 1; i >= 0; i-- {
+		if allHooks[i].OnStop != nil {
+			order = append(order, i)
+		}
+	}
+	modules := l.newModuleHooks("OnStop", order)
+
 	// Run backward from last successful OnStart.
 	var errs []error
 	for ; numStarted > 0; numStarted-- {
 		if err := ctx.Err(); err != nil {
+			modules.finish(err)
 			return err
 		}
 		hook := allHooks[numStarted-1]
@@ -300,7 +389,9 @@ func (l *Lifecycle) Stop(ctx context.Context) error {
 		l.runningHook = hook
 		l.mu.Unlock()
 
-		runtime, err := l.runStopHook(ctx, hook)
+		modules.before(numStarted - 1)
+		runtime, err := l.runStopHook(ctx, numStarted-1, hook)
+		modules.after(numStarted-1, runtime, err)
 		if err != nil {
 			// For best-effort cleanup, keep going after errors.
 			errs = append(errs, err)
@@ -308,21 +399,21 @@ func (l *Lifecycle) Stop(ctx context.Context) error {
 
 		l.mu.Lock()
 		l.stopRecords = append(l.stopRecords, HookRecord{
+			Index:       numStarted - 1,
 			CallerFrame: hook.callerFrame,
 			Func:        hook.OnStop,
+			Name:        hook.onStopName(),
 			Runtime:     runtime,
 		})
 		l.mu.Unlock()
 	}
 
+	modules.finish(nil)
 	return multierr.Combine(errs...)
 }
 
-func (l *Lifecycle) runStopHook(ctx context.Context, hook Hook) (runtime time.Duration, err error) {
-	funcName := hook.OnStopName
-	if len(funcName) == 0 {
-		funcName = fxreflect.FuncName(hook.OnStop)
-	}
+func (l *Lifecycle) runStopHook(ctx context.Context, index int, hook Hook) (runtime time.Duration, err error) {
+	funcName := hook.onStopName()
 
 	l.logger.LogEvent(&fxevent.OnStopExecuting{
 		CallerName:   hook.callerFrame.Function,
@@ -337,11 +428,103 @@ func (l *Lifecycle) runStopHook(ctx context.Context, hook Hook) (runtime time.Du
 		})
 	}()
 
+	if l.slowThreshold > 0 {
+		defer l.watch(hook, "OnStop", funcName)()
+	}
+
+	fn := l.hookFunc("OnStop", index, funcName, hook.OnStop)
 	begin := l.clock.Now()
-	err = hook.OnStop(ctx)
+	err = fn(ctx)
 	return l.clock.Since(begin), err
 }
 
+// hookFunc returns the function to run for the hook at index,
+// applying the interceptor if any.
+func (l *Lifecycle) hookFunc(method string, index int, funcName string, fn func(context.Context) error) func(context.Context) error {
+	if l.intercept != nil {
+		call := HookCall{Method: method, FunctionName: funcName}
+		if l.moduleOf != nil {
+			_, call.ModuleName = l.moduleOf(index)
+		}
+		fn = l.intercept(call, fn)
+	}
+	return fn
+}
+
+// watch starts a watchdog for a hook that's about to run.
+// The returned function must be called when the hook finishes.
+func (l *Lifecycle) watch(hook Hook, method, funcName string) (stop func()) {
+	return fxclock.Watch(l.clock, l.slowThreshold, func(runtime time.Duration) {
+		l.logger.LogEvent(&fxevent.SlowHook{
+			FunctionName: funcName,
+			CallerName:   hook.callerFrame.Function,
+			Method:       method,
+			Threshold:    l.slowThreshold,
+			Runtime:      runtime,
+		})
+	})
+}
+
+// State returns the current state of the lifecycle:
+// one of "stopped", "starting", "incompleteStart", "started", or "stopping".
+func (l *Lifecycle) State() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state.String()
+}
+
+// HookInfo describes a hook that was appended to the lifecycle.
+type HookInfo struct {
+	OnStartName string          // empty if the hook has no OnStart
+	OnStopName  string          // empty if the hook has no OnStop
+	CallerFrame fxreflect.Frame // stack frame of the caller of Append
+
+	// Started reports whether the lifecycle got past this hook
+	// during the most recent call to Start.
+	Started bool
+}
+
+// Hooks returns the hooks appended to the lifecycle,
+// in the order they were appended.
+func (l *Lifecycle) Hooks() []HookInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	infos := make([]HookInfo, len(l.hooks))
+	for i, h := range l.hooks {
+		infos[i] = HookInfo{
+			OnStartName: h.onStartName(),
+			OnStopName:  h.onStopName(),
+			CallerFrame: h.callerFrame,
+			Started:     i < l.numStarted,
+		}
+	}
+	return infos
+}
+
+// NumHooks returns the number of hooks appended to the lifecycle.
+func (l *Lifecycle) NumHooks() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.hooks)
+}
+
+// StartRecords returns the OnStart hooks that ran successfully
+// during the most recent call to Start, in the order they ran.
+func (l *Lifecycle) StartRecords() HookRecords {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append(HookRecords(nil), l.startRecords...)
+}
+
+// StopRecords returns the OnStop hooks that ran
+// during the most recent call to Stop, in the order they ran.
+func (l *Lifecycle) StopRecords() HookRecords {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append(HookRecords(nil), l.stopRecords...)
+}
+
 // RunningHookCaller returns the name of the hook that was running when a Start/Stop
 // hook timed out.
 func (l *Lifecycle) RunningHookCaller() string {
@@ -352,8 +535,10 @@ func (l *Lifecycle) RunningHookCaller() string {
 
 // HookRecord keeps track of each Hook's execution time, the caller that appended the Hook, and function that ran as the Hook.
 type HookRecord struct {
+	Index       int                         // index of the hook in the lifecycle
 	CallerFrame fxreflect.Frame             // stack frame of the caller
 	Func        func(context.Context) error // function that ran as sanitized name
+	Name        string                      // name of the function that ran
 	Runtime     time.Duration               // how long the hook ran
 }
 
@@ -399,3 +584,122 @@ func (rs HookRecords) Format(w fmt.State, c rune) {
 	}
 	fmt.Fprintf(w, "\n")
 }
+
+// moduleHooks logs ModuleHooksExecuting and ModuleHooksExecuted events
+// while the OnStart or OnStop hooks of the lifecycle run.
+//
+// A nil *moduleHooks logs nothing.
+type moduleHooks struct {
+	logger   fxevent.Logger
+	method   string
+	moduleOf func(index int) (key interface{}, name string)
+
+	// Index of the last hook of each module that will run,
+	// by module key.
+	last map[interface{}]int
+
+	// Modules with hooks that have started running,
+	// in the order they started.
+	open []*moduleHooksRun
+}
+
+type moduleHooksRun struct {
+	key     interface{}
+	name    string
+	runtime time.Duration
+	errs    []error
+}
+
+// newModuleHooks builds a moduleHooks for the hooks at the given indexes,
+// listed in the order they'll run.
+func (l *Lifecycle) newModuleHooks(method string, order []int) *moduleHooks {
+	if l.moduleOf == nil {
+		return nil
+	}
+
+	last := make(map[interface{}]int)
+	for _, i := range order {
+		if key, _ := l.moduleOf(i); key != nil {
+			last[key] = i
+		}
+	}
+	return &moduleHooks{
+		logger:   l.logger,
+		method:   method,
+		moduleOf: l.moduleOf,
+		last:     last,
+	}
+}
+
+// before is called before the hook at index i runs.
+func (m *moduleHooks) before(i int) {
+	if m == nil {
+		return
+	}
+	key, name := m.moduleOf(i)
+	if key == nil || m.find(key) >= 0 {
+		return
+	}
+
+	m.open = append(m.open, &moduleHooksRun{key: key, name: name})
+	m.logger.LogEvent(&fxevent.ModuleHooksExecuting{
+		ModuleName: name,
+		Method:     m.method,
+	})
+}
+
+// after is called after the hook at index i runs.
+func (m *moduleHooks) after(i int, runtime time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	key, _ := m.moduleOf(i)
+	idx := m.find(key)
+	if key == nil || idx < 0 {
+		return
+	}
+
+	run := m.open[idx]
+	run.runtime += runtime
+	if err != nil {
+		run.errs = append(run.errs, err)
+	}
+	if last, ok := m.last[key]; ok && last == i {
+		m.open = append(m.open[:idx], m.open[idx+1:]...)
+		m.executed(run, nil)
+	}
+}
+
+// finish is called after the lifecycle stops running hooks.
+// Modules with hooks that didn't run because of err are reported too.
+func (m *moduleHooks) finish(err error) {
+	if m == nil {
+		return
+	}
+	for _, run := range m.open {
+		m.executed(run, err)
+	}
+	m.open = nil
+}
+
+func (m *moduleHooks) executed(run *moduleHooksRun, interrupted error) {
+	err := multierr.Combine(run.errs...)
+	if err == nil {
+		err = interrupted
+	}
+	m.logger.LogEvent(&fxevent.ModuleHooksExecuted{
+		ModuleName: run.name,
+		Method:     m.method,
+		Runtime:    run.runtime,
+		Err:        err,
+	})
+}
+
+func (m *moduleHooks) find(key interface{}) int {
+	for i, run := range m.open {
+		if run.key == key {
+			return i
+		}
+	}
+	return -1
+}
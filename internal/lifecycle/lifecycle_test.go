@@ -330,6 +330,237 @@ func TestLifecycleStop(t *testing.T) {
 	})
 }
 
+func TestLifecycleInspection(t *testing.T) {
+	t.Parallel()
+
+	l := New(testLogger(t), fxclock.System)
+	assert.Equal(t, "stopped", l.State())
+
+	l.Append(Hook{
+		OnStart:     func(context.Context) error { return nil },
+		OnStartName: "first",
+	})
+	l.Append(Hook{
+		OnStart:    func(context.Context) error { return errors.New("great sadness") },
+		OnStop:     func(context.Context) error { return nil },
+		OnStopName: "second",
+	})
+
+	hooks := l.Hooks()
+	require.Len(t, hooks, 2)
+	assert.Equal(t, "first", hooks[0].OnStartName)
+	assert.Empty(t, hooks[0].OnStopName)
+	assert.Contains(t, hooks[1].OnStartName, "TestLifecycleInspection.func2()")
+	assert.Equal(t, "second", hooks[1].OnStopName)
+	assert.False(t, hooks[0].Started)
+
+	require.Error(t, l.Start(context.Background()))
+	assert.Equal(t, "incompleteStart", l.State())
+
+	hooks = l.Hooks()
+	assert.True(t, hooks[0].Started)
+	assert.False(t, hooks[1].Started)
+
+	records := l.StartRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, "first", records[0].Name)
+
+	require.NoError(t, l.Stop(context.Background()))
+	assert.Equal(t, "stopped", l.State())
+	assert.Empty(t, l.StopRecords(), "second hook never started")
+}
+
+func TestHookRecordIndex(t *testing.T) {
+	t.Parallel()
+
+	l := New(testLogger(t), fxclock.System)
+	l.Append(Hook{OnStop: func(context.Context) error { return nil }})
+	l.Append(Hook{OnStart: func(context.Context) error { return nil }})
+	l.Append(Hook{
+		OnStart: func(context.Context) error { return nil },
+		OnStop:  func(context.Context) error { return nil },
+	})
+	assert.Equal(t, 3, l.NumHooks())
+
+	require.NoError(t, l.Start(context.Background()))
+	records := l.StartRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, 1, records[0].Index)
+	assert.Equal(t, 2, records[1].Index)
+
+	require.NoError(t, l.Stop(context.Background()))
+	records = l.StopRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, 2, records[0].Index)
+	assert.Equal(t, 0, records[1].Index)
+}
+
+func TestModuleHooks(t *testing.T) {
+	t.Parallel()
+
+	noop := func(context.Context) error { return nil }
+
+	// moduleEvents returns the module events logged to spy,
+	// formatted as strings.
+	moduleEvents := func(spy *fxlog.Spy) []string {
+		var events []string
+		for _, e := range spy.Events() {
+			switch e := e.(type) {
+			case *fxevent.ModuleHooksExecuting:
+				events = append(events, fmt.Sprintf("executing %v %v", e.ModuleName, e.Method))
+			case *fxevent.ModuleHooksExecuted:
+				events = append(events, fmt.Sprintf("executed %v %v: %v", e.ModuleName, e.Method, e.Err))
+			}
+		}
+		return events
+	}
+
+	// modulesOf reports the hook at each index as appended by
+	// the module with the name at that index, if any.
+	modulesOf := func(names ...string) func(int) (interface{}, string) {
+		return func(i int) (interface{}, string) {
+			if names[i] == "" {
+				return nil, ""
+			}
+			return names[i], names[i]
+		}
+	}
+
+	t.Run("interleaved", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.SetModuleOf(modulesOf("a", "", "b", "a"))
+		l.Append(Hook{OnStart: noop, OnStop: noop})
+		l.Append(Hook{OnStart: noop})
+		l.Append(Hook{OnStart: noop, OnStop: noop})
+		l.Append(Hook{OnStart: noop, OnStop: noop})
+
+		require.NoError(t, l.Start(context.Background()))
+		assert.Equal(t, []string{
+			"executing a OnStart",
+			"executing b OnStart",
+			"executed b OnStart: <nil>",
+			"executed a OnStart: <nil>",
+		}, moduleEvents(spy))
+
+		spy.Reset()
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"executing a OnStop",
+			"executing b OnStop",
+			"executed b OnStop: <nil>",
+			"executed a OnStop: <nil>",
+		}, moduleEvents(spy))
+	})
+
+	t.Run("start failure", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.SetModuleOf(modulesOf("a", "b", "a"))
+		l.Append(Hook{OnStart: noop})
+		l.Append(Hook{OnStart: func(context.Context) error { return errors.New("great sadness") }})
+		l.Append(Hook{OnStart: noop})
+
+		require.Error(t, l.Start(context.Background()))
+		assert.Equal(t, []string{
+			"executing a OnStart",
+			"executing b OnStart",
+			"executed b OnStart: great sadness",
+			"executed a OnStart: great sadness",
+		}, moduleEvents(spy))
+	})
+
+	t.Run("stop failure", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.SetModuleOf(modulesOf("a", "a", "a"))
+		l.Append(Hook{OnStop: noop})
+		l.Append(Hook{OnStop: func(context.Context) error { return errors.New("great sadness") }})
+		l.Append(Hook{OnStop: noop})
+
+		require.NoError(t, l.Start(context.Background()))
+		require.Error(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"executing a OnStop",
+			"executed a OnStop: great sadness",
+		}, moduleEvents(spy))
+	})
+
+	t.Run("same name", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		// Two different modules named "a".
+		type key struct{ id int }
+		keys := []key{{1}, {2}, {1}}
+		l.SetModuleOf(func(i int) (interface{}, string) { return keys[i], "a" })
+		l.Append(Hook{OnStart: noop})
+		l.Append(Hook{OnStart: noop})
+		l.Append(Hook{OnStart: noop})
+
+		require.NoError(t, l.Start(context.Background()))
+		assert.Equal(t, []string{
+			"executing a OnStart",
+			"executing a OnStart",
+			"executed a OnStart: <nil>",
+			"executed a OnStart: <nil>",
+		}, moduleEvents(spy))
+	})
+
+	t.Run("no modules", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.Append(Hook{OnStart: noop, OnStop: noop})
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Empty(t, moduleEvents(spy))
+	})
+}
+
+func TestInterceptor(t *testing.T) {
+	t.Parallel()
+
+	noop := func(context.Context) error { return nil }
+	giveErr := errors.New("great sadness")
+
+	l := New(testLogger(t), fxclock.System)
+	l.SetModuleOf(func(i int) (interface{}, string) {
+		name := []string{"a", "b"}[i]
+		return name, name
+	})
+
+	var calls []HookCall
+	l.SetInterceptor(func(call HookCall, fn func(context.Context) error) func(context.Context) error {
+		calls = append(calls, call)
+		if call.Method == "OnStop" && call.ModuleName == "a" {
+			return func(context.Context) error { return giveErr }
+		}
+		return fn
+	})
+
+	l.Append(Hook{OnStart: noop, OnStartName: "startA", OnStop: noop, OnStopName: "stopA"})
+	l.Append(Hook{OnStart: noop, OnStartName: "startB", OnStop: noop, OnStopName: "stopB"})
+
+	require.NoError(t, l.Start(context.Background()))
+	assert.ErrorIs(t, l.Stop(context.Background()), giveErr)
+	assert.Equal(t, []HookCall{
+		{Method: "OnStart", FunctionName: "startA", ModuleName: "a"},
+		{Method: "OnStart", FunctionName: "startB", ModuleName: "b"},
+		{Method: "OnStop", FunctionName: "stopB", ModuleName: "b"},
+		{Method: "OnStop", FunctionName: "stopA", ModuleName: "a"},
+	}, calls)
+}
+
 func TestHookRecordsFormat(t *testing.T) {
 	t.Parallel()
 
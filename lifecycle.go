@@ -135,13 +135,25 @@ func StartStopHook[T1 HookFunc, T2 HookFunc](start T1, stop T2) Hook {
 
 type lifecycleWrapper struct {
 	*lifecycle.Lifecycle
+
+	construct *constructGuard
 }
 
 func (l *lifecycleWrapper) Append(h Hook) {
-	l.Lifecycle.Append(lifecycle.Hook{
+	// Not construct.do: Append reports its caller,
+	// so it must be called directly from here.
+	l.construct.mu.Lock()
+	defer l.construct.mu.Unlock()
+	if !l.construct.abandoned {
+		l.Lifecycle.Append(h.lifecycleHook())
+	}
+}
+
+func (h Hook) lifecycleHook() lifecycle.Hook {
+	return lifecycle.Hook{
 		OnStart:     h.OnStart,
 		OnStop:      h.OnStop,
 		OnStartName: h.onStartName,
 		OnStopName:  h.onStopName,
-	})
+	}
 }
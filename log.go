@@ -21,18 +21,49 @@
 package fx
 
 import (
+	"sync"
+
 	"go.uber.org/fx/fxevent"
 )
 
+// syncLogger delivers the events of an App to a logger one at a time.
+//
+// Watchdogs for slow functions log events from their own goroutines,
+// but fxevent.Logger implementations don't need to be safe for
+// concurrent use, so all loggers of an App share a mutex.
+type syncLogger struct {
+	mu     *sync.Mutex
+	logger fxevent.Logger
+}
+
+// syncLogger wraps logger so that it's never called concurrently
+// with the other loggers of the App.
+func (app *App) syncLogger(logger fxevent.Logger) fxevent.Logger {
+	return syncLogger{mu: &app.logMu, logger: logger}
+}
+
+func (l syncLogger) LogEvent(event fxevent.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.logger.LogEvent(event)
+}
+
 // logBuffer will buffer all messages until a logger has been
 // initialized.
+//
+// Events may be logged concurrently by watchdogs for slow functions.
 type logBuffer struct {
+	mu     sync.Mutex
 	events []fxevent.Event
 	logger fxevent.Logger
 }
 
 // LogEvent buffers or logs an event.
 func (l *logBuffer) LogEvent(event fxevent.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	if l.logger == nil {
 		l.events = append(l.events, event)
 	} else {
@@ -42,6 +73,9 @@ func (l *logBuffer) LogEvent(event fxevent.Event) {
 
 // Connect flushes out all buffered events to a logger and resets them.
 func (l *logBuffer) Connect(logger fxevent.Logger) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
 	l.logger = logger
 	for _, e := range l.events {
 		logger.LogEvent(e)
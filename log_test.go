@@ -21,6 +21,9 @@
 package fx
 
 import (
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -61,6 +64,44 @@ func TestLogBufferLog(t *testing.T) {
 	assert.Equal(t, fxlog.Events{event}, spy.Events())
 }
 
+// overlapLogger records whether LogEvent was called concurrently.
+type overlapLogger struct {
+	active  int32
+	overlap atomic.Bool
+}
+
+func (l *overlapLogger) LogEvent(fxevent.Event) {
+	if atomic.AddInt32(&l.active, 1) > 1 {
+		l.overlap.Store(true)
+	}
+	runtime.Gosched()
+	atomic.AddInt32(&l.active, -1)
+}
+
+func TestSyncLogger(t *testing.T) {
+	t.Parallel()
+
+	var (
+		app    App
+		logger overlapLogger
+		wg     sync.WaitGroup
+	)
+	// Loggers of different modules may share an implementation.
+	loggers := []fxevent.Logger{app.syncLogger(&logger), app.syncLogger(&logger)}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(log fxevent.Logger) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				log.LogEvent(&fxevent.Started{})
+			}
+		}(loggers[i%len(loggers)])
+	}
+	wg.Wait()
+
+	assert.False(t, logger.overlap.Load(), "LogEvent must not be called concurrently")
+}
+
 func TestWithLoggerDecorate(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,220 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/fx/internal/fxdeps"
+)
+
+// providedOutput is a single value that a constructor added to the
+// container, along with where it was provided.
+type providedOutput struct {
+	Key         fxdeps.Key
+	Constructor *constructorInfo
+}
+
+func (o providedOutput) String() string {
+	c := o.Constructor
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v provided by %v from %v", o.Key, c.Name, c.Location)
+	if c.Private {
+		fmt.Fprintf(&sb, " (private to module %q)", c.ModuleName)
+	} else if c.ModuleName != "" {
+		fmt.Fprintf(&sb, " in module %q", c.ModuleName)
+	}
+	return sb.String()
+}
+
+// isCloseMatch reports whether the provided key got is likely to be what
+// the user meant when they asked for the missing key want.
+func isCloseMatch(want, got fxdeps.Key) bool {
+	switch {
+	case want == got:
+		// Exact match that wasn't visible.
+		// This happens when the value was provided privately
+		// inside another module.
+		return true
+	case want.Type == got.Type:
+		// Same type under a different name or group.
+		return true
+	case got.Type == reflect.PointerTo(want.Type), want.Type == reflect.PointerTo(got.Type):
+		// Pointer instead of a value, or vice versa.
+		return true
+	}
+
+	// Interface instead of an implementation, or vice versa,
+	// under the same name or group.
+	if want.Name != got.Name || want.Group != got.Group {
+		return false
+	}
+	return implements(got.Type, want.Type) || implements(want.Type, got.Type)
+}
+
+// implements reports whether t implements iface,
+// which must be an interface with at least one method.
+func implements(t, iface reflect.Type) bool {
+	return iface.Kind() == reflect.Interface && iface.NumMethod() > 0 && t.Implements(iface)
+}
+
+// missingDependencyError wraps a Dig error for missing dependencies,
+// adding the values in the container that the user may have meant.
+type missingDependencyError struct {
+	err     error
+	matches []providedOutput
+}
+
+func (e *missingDependencyError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.err.Error())
+	sb.WriteString("\ndid you mean one of the following?")
+	for _, m := range e.matches {
+		fmt.Fprintf(&sb, "\n\t- %v", m)
+	}
+	return sb.String()
+}
+
+func (e *missingDependencyError) Unwrap() error {
+	return e.err
+}
+
+// suggestMissing annotates err, returned by Dig for a function that was run
+// in this module with the given inputs, with close matches for any values
+// that the function needs but are missing from the container.
+//
+// If nothing is missing, or there are no close matches,
+// err is returned as-is.
+func (m *module) suggestMissing(err error, inputs []fxdeps.Input) error {
+	w := missingWalker{
+		app:  m.app,
+		seen: make(map[interface{}]bool),
+	}
+	w.walk(m, inputs)
+	if len(w.missing) == 0 {
+		return err
+	}
+
+	var matches []providedOutput
+	for _, c := range m.app.constructors {
+		for _, out := range c.OutputKeys {
+			for _, want := range w.missing {
+				if isCloseMatch(want, out) {
+					matches = append(matches, providedOutput{Key: out, Constructor: c})
+					break
+				}
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return err
+	}
+	return &missingDependencyError{err: err, matches: matches}
+}
+
+// missingWalker finds the values that a function needs,
+// directly or through the constructors and decorators
+// that Dig runs to build its inputs,
+// but that aren't visible to the module that needs them.
+type missingWalker struct {
+	app     *App
+	seen    map[interface{}]bool // constructors and decorators already walked
+	missing []fxdeps.Key
+}
+
+// decoratorRef identifies a decorator by the module it was given to.
+type decoratorRef struct {
+	module *module
+	index  int
+}
+
+func (w *missingWalker) walk(m *module, inputs []fxdeps.Input) {
+	for _, in := range inputs {
+		providers := w.app.visibleConstructors(m, in.Key)
+		if len(providers) == 0 && !in.Optional && in.Group == "" {
+			w.addMissing(in.Key)
+		}
+		for _, c := range providers {
+			if !w.seen[c] {
+				w.seen[c] = true
+				w.walk(c.Module, c.InputKeys)
+			}
+		}
+
+		// Decorators in this module and its ancestors
+		// also run to build the value.
+		for dm := m; dm != nil; dm = dm.parent {
+			for i, d := range dm.decorators {
+				ref := decoratorRef{module: dm, index: i}
+				if d.IsReplace || w.seen[ref] {
+					continue
+				}
+				ins, outs := decoratorDeps(d)
+				if containsKey(outs, in.Key) {
+					w.seen[ref] = true
+					w.walk(dm, ins)
+				}
+			}
+		}
+	}
+}
+
+func (w *missingWalker) addMissing(k fxdeps.Key) {
+	if !containsKey(w.missing, k) {
+		w.missing = append(w.missing, k)
+	}
+}
+
+// visibleConstructors returns the constructors that provide k
+// to functions run in module m.
+// Values provided with fx.Private are only visible
+// to the module they were provided to and its descendants.
+func (app *App) visibleConstructors(m *module, k fxdeps.Key) []*constructorInfo {
+	var found []*constructorInfo
+	for _, c := range app.constructors {
+		if containsKey(c.OutputKeys, k) && (!c.Private || c.Module.isAncestorOf(m)) {
+			found = append(found, c)
+		}
+	}
+	return found
+}
+
+// isAncestorOf reports whether m is other or one of its ancestors.
+func (m *module) isAncestorOf(other *module) bool {
+	for ; other != nil; other = other.parent {
+		if other == m {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKey(keys []fxdeps.Key, k fxdeps.Key) bool {
+	for _, key := range keys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
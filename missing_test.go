@@ -0,0 +1,166 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+func TestMissingDependencySuggestions(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+
+	tests := []struct {
+		desc        string
+		opts        []fx.Option
+		wantMissing string
+		wantMatches []string
+	}{
+		{
+			desc: "name typo",
+			opts: []fx.Option{
+				fx.Provide(
+					fx.Annotate(func() string { return "" }, fx.ResultTags(`name:"foo"`)),
+				),
+				fx.Invoke(fx.Annotate(func(string) {}, fx.ParamTags(`name:"fo"`))),
+			},
+			wantMissing: `missing type: string[name="fo"]`,
+			wantMatches: []string{`string[name="foo"] provided by fx.Annotate(`},
+		},
+		{
+			desc: "value instead of pointer",
+			opts: []fx.Option{
+				fx.Provide(func() A { return A{} }),
+				fx.Invoke(func(*A) {}),
+			},
+			wantMissing: "missing type: *fx_test.A",
+			wantMatches: []string{"fx_test.A provided by go.uber.org/fx_test.TestMissingDependencySuggestions"},
+		},
+		{
+			desc: "implementation instead of interface",
+			opts: []fx.Option{
+				fx.Provide(func() *bytes.Buffer { return new(bytes.Buffer) }),
+				fx.Invoke(func(io.Writer) {}),
+			},
+			wantMissing: "missing type: io.Writer",
+			wantMatches: []string{"*bytes.Buffer provided by go.uber.org/fx_test.TestMissingDependencySuggestions"},
+		},
+		{
+			desc: "private to another module",
+			opts: []fx.Option{
+				fx.Module("db",
+					fx.Provide(func() *A { return &A{} }, fx.Private),
+				),
+				fx.Invoke(func(*A) {}),
+			},
+			wantMissing: "missing type: *fx_test.A",
+			wantMatches: []string{`(private to module "db")`},
+		},
+		{
+			desc: "supplied under a name",
+			opts: []fx.Option{
+				fx.Supply(fx.Annotated{Name: "a", Target: &A{}}),
+				fx.Invoke(func(*A) {}),
+			},
+			wantMissing: "missing type: *fx_test.A",
+			wantMatches: []string{`*fx_test.A[name="a"] provided by fx.Supply(*fx_test.A)`},
+		},
+		{
+			desc: "needed by a constructor",
+			opts: []fx.Option{
+				fx.Provide(func(*B) *A { return &A{} }),
+				fx.Provide(func() B { return B{} }),
+				fx.Invoke(func(*A) {}),
+			},
+			wantMissing: "missing type: *fx_test.B",
+			wantMatches: []string{"fx_test.B provided by go.uber.org/fx_test.TestMissingDependencySuggestions"},
+		},
+		{
+			desc: "needed by a decorator",
+			opts: []fx.Option{
+				fx.Provide(func() *A { return &A{} }),
+				fx.Provide(func() B { return B{} }),
+				fx.Decorate(func(a *A, _ *B) *A { return a }),
+				fx.Invoke(func(*A) {}),
+			},
+			wantMissing: "missing type: *fx_test.B",
+			wantMatches: []string{"fx_test.B provided by go.uber.org/fx_test.TestMissingDependencySuggestions"},
+		},
+		{
+			desc: "needed by the logger",
+			opts: []fx.Option{
+				fx.Provide(func() B { return B{} }),
+				fx.WithLogger(func(*B) fxevent.Logger { return fxevent.NopLogger }),
+			},
+			wantMissing: "missing type: *fx_test.B",
+			wantMatches: []string{"fx_test.B provided by go.uber.org/fx_test.TestMissingDependencySuggestions"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := NewForTest(t, tt.opts...).Err()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantMissing)
+			assert.Contains(t, err.Error(), "did you mean one of the following?")
+			for _, m := range tt.wantMatches {
+				assert.Contains(t, err.Error(), m)
+			}
+		})
+	}
+
+	t.Run("no close matches", func(t *testing.T) {
+		t.Parallel()
+
+		err := NewForTest(t,
+			fx.Provide(func() *A { return &A{} }),
+			fx.Invoke(func(*B) {}),
+		).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing type: *fx_test.B")
+		assert.NotContains(t, err.Error(), "did you mean one of the following?")
+	})
+
+	t.Run("not a missing dependency", func(t *testing.T) {
+		t.Parallel()
+
+		giveErr := errors.New("great sadness")
+		err := NewForTest(t,
+			fx.Provide(func() *A { return &A{} }),
+			fx.Invoke(func(*A) error { return giveErr }),
+		).Err()
+		assert.ErrorIs(t, err, giveErr)
+		assert.NotContains(t, err.Error(), "did you mean")
+	})
+}
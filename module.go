@@ -22,9 +22,11 @@ package fx
 
 import (
 	"fmt"
+	"time"
 
 	"go.uber.org/dig"
 	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxdeps"
 	"go.uber.org/fx/internal/fxreflect"
 	"go.uber.org/multierr"
 )
@@ -106,10 +108,11 @@ func (o moduleOption) apply(mod *module) {
 	// Create trace as parent's trace with this module's location pre-pended.
 	trace := append([]string{fmt.Sprintf("%v (%v)", o.location, o.name)}, mod.trace...)
 	newModule := &module{
-		name:   o.name,
-		parent: mod,
-		trace:  trace,
-		app:    mod.app,
+		name:     o.name,
+		location: o.location,
+		parent:   mod,
+		trace:    trace,
+		app:      mod.app,
 	}
 	for _, opt := range o.options {
 		opt.apply(newModule)
@@ -120,6 +123,7 @@ func (o moduleOption) apply(mod *module) {
 type module struct {
 	parent         *module
 	name           string
+	location       fxreflect.Frame
 	trace          []string
 	scope          scope
 	provides       []provide
@@ -130,6 +134,13 @@ type module struct {
 	log            fxevent.Logger
 	fallbackLogger fxevent.Logger
 	logConstructor *provide
+
+	// Time spent running the constructors, decorators, and invokes
+	// of this module, not counting its submodules.
+	runtime time.Duration
+
+	// Whether Dig has run each decorator, by index in decorators.
+	decoratorRan []bool
 }
 
 // scope is a private wrapper interface for dig.Container and dig.Scope.
@@ -155,6 +166,7 @@ func (m *module) build(app *App, root *dig.Container) {
 		m.scope = parentScope.Scope(m.name)
 		// use parent module's logger by default
 		m.log = m.parent.log
+		m.provideAppContext()
 	}
 
 	if m.logConstructor != nil {
@@ -191,28 +203,38 @@ func (m *module) provide(p provide) {
 	}
 
 	funcName := fxreflect.FuncName(p.Target)
-	var info dig.ProvideInfo
+	var (
+		info   dig.ProvideInfo
+		record *constructorInfo // set once provided
+	)
 	opts := []dig.ProvideOption{
 		dig.FillProvideInfo(&info),
 		dig.Export(!p.Private),
 		dig.WithProviderCallback(func(ci dig.CallbackInfo) {
-			m.log.LogEvent(&fxevent.Run{
+			var ran *bool
+			if record != nil {
+				ran = &record.Ran
+			}
+			m.logRun(&fxevent.Run{
 				Name:       funcName,
 				Kind:       "provide",
 				ModuleName: m.name,
 				Runtime:    ci.Runtime,
 				Err:        ci.Error,
-			})
+			}, ran)
 		}),
 	}
 
+	if m.app.watchesConstructors() {
+		p, opts = m.watchProvide(p, funcName, opts)
+	}
+
 	if err := runProvide(m.scope, p, opts...); err != nil {
 		m.app.err = err
 	}
-	outputNames := make([]string, len(info.Outputs))
-	for i, o := range info.Outputs {
-		outputNames[i] = o.String()
-	}
+	outputNames := typeNames(info.Outputs)
+	inputNames := typeNames(info.Inputs)
+	record = m.recordConstructor(p, funcName, "provide", inputNames, outputNames)
 
 	m.log.LogEvent(&fxevent.Provided{
 		ConstructorName: funcName,
@@ -220,6 +242,7 @@ func (m *module) provide(p provide) {
 		ModuleTrace:     append([]string{p.Stack[0].String()}, m.trace...),
 		ModuleName:      m.name,
 		OutputTypeNames: outputNames,
+		InputTypeNames:  inputNames,
 		Err:             m.app.err,
 		Private:         p.Private,
 	})
@@ -227,21 +250,25 @@ func (m *module) provide(p provide) {
 
 func (m *module) supply(p provide) {
 	typeName := p.SupplyType.String()
+	var info dig.ProvideInfo
 	opts := []dig.ProvideOption{
+		dig.FillProvideInfo(&info),
 		dig.Export(!p.Private),
 		dig.WithProviderCallback(func(ci dig.CallbackInfo) {
-			m.log.LogEvent(&fxevent.Run{
+			m.logRun(&fxevent.Run{
 				Name:       fmt.Sprintf("stub(%v)", typeName),
 				Kind:       "supply",
 				Runtime:    ci.Runtime,
 				ModuleName: m.name,
-			})
+			}, nil)
 		}),
 	}
 
 	if err := runProvide(m.scope, p, opts...); err != nil {
 		m.app.err = err
 	}
+	m.recordConstructor(p, fmt.Sprintf("fx.Supply(%v)", typeName), "supply",
+		typeNames(info.Inputs), typeNames(info.Outputs))
 
 	m.log.LogEvent(&fxevent.Supplied{
 		TypeName:    typeName,
@@ -252,6 +279,58 @@ func (m *module) supply(p provide) {
 	})
 }
 
+// constructorInfo describes a constructor or supplied value
+// that was added to the container.
+type constructorInfo struct {
+	Name       string
+	Kind       string // "provide" or "supply"
+	Location   fxreflect.Frame
+	ModuleName string
+	Private    bool
+	Default    bool // provided by Fx to every application
+	Ran        bool // whether the constructor was called
+	Module     *module
+
+	// Values consumed and produced, as reported by Dig.
+	Inputs  []string
+	Outputs []string
+
+	// The same values, as keys in the container.
+	InputKeys  []fxdeps.Input
+	OutputKeys []fxdeps.Key
+}
+
+// recordConstructor remembers what p consumes and produces so that the
+// application's wiring can be inspected after it has been built.
+func (m *module) recordConstructor(p provide, name, kind string, inputs, outputs []string) *constructorInfo {
+	c := &constructorInfo{
+		Name:       name,
+		Kind:       kind,
+		ModuleName: m.name,
+		Private:    p.Private,
+		Default:    p.IsDefault,
+		Module:     m,
+		Inputs:     inputs,
+		Outputs:    outputs,
+	}
+	if len(p.Stack) > 0 {
+		c.Location = p.Stack[0]
+	}
+	c.InputKeys, c.OutputKeys = provideDeps(p)
+	m.app.constructors = append(m.app.constructors, c)
+	return c
+}
+
+// typeNames formats the inputs or outputs that Dig reports
+// for a constructor or decorator.
+func typeNames[T fmt.Stringer](ts []T) []string {
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = t.String()
+	}
+	return names
+}
+
 // Constructs custom loggers for all modules in the tree
 func (m *module) installAllEventLoggers() {
 	if m.logConstructor != nil {
@@ -290,13 +369,34 @@ func (m *module) installEventLogger(buffer *logBuffer) (err error) {
 			fname, p.Stack, m.name, err)
 	}
 
-	return m.scope.Invoke(func(log fxevent.Logger) {
-		m.log = log
-		buffer.Connect(log)
+	err = m.scope.Invoke(func(log fxevent.Logger) {
+		m.log = m.app.syncLogger(log)
+		buffer.Connect(m.log)
 	})
+	if err != nil {
+		inputs, _ := provideDeps(*p)
+		err = m.suggestMissing(err, inputs)
+	}
+	return err
 }
 
-func (m *module) invokeAll() error {
+func (m *module) invokeAll() (err error) {
+	if m.app.construct.isAbandoned() {
+		return errConstructAbandoned
+	}
+	if m.parent != nil {
+		m.app.construct.do(func() {
+			m.log.LogEvent(&fxevent.ModuleBuilding{ModuleName: m.name})
+		})
+		defer m.app.construct.do(func() {
+			m.log.LogEvent(&fxevent.ModuleBuilt{
+				ModuleName: m.name,
+				Runtime:    m.runtime,
+				Err:        err,
+			})
+		})
+	}
+
 	for _, m := range m.modules {
 		if err := m.invokeAll(); err != nil {
 			return err
@@ -304,6 +404,10 @@ func (m *module) invokeAll() error {
 	}
 
 	for _, invoke := range m.invokes {
+		// Stop once NewContext has given up on the application.
+		if m.app.construct.isAbandoned() {
+			return errConstructAbandoned
+		}
 		if err := m.invoke(invoke); err != nil {
 			return err
 		}
@@ -314,23 +418,49 @@ func (m *module) invokeAll() error {
 
 func (m *module) invoke(i invoke) (err error) {
 	fnName := fxreflect.FuncName(i.Target)
-	m.log.LogEvent(&fxevent.Invoking{
-		FunctionName: fnName,
-		ModuleName:   m.name,
+	m.app.construct.do(func() {
+		m.log.LogEvent(&fxevent.Invoking{
+			FunctionName: fnName,
+			ModuleName:   m.name,
+		})
 	})
-	err = runInvoke(m.scope, i)
-	m.log.LogEvent(&fxevent.Invoked{
-		FunctionName: fnName,
-		ModuleName:   m.name,
-		Err:          err,
-		Trace:        fmt.Sprintf("%+v", i.Stack), // format stack trace as multi-line
+	if m.app.constructCtx != nil {
+		defer m.app.running.push(runningFunc{
+			kind:       "invoke",
+			name:       fnName,
+			moduleName: m.name,
+		})()
+	}
+	// Dig runs the constructors that the invoke depends on first.
+	// Their time is charged to the modules that they belong to,
+	// so only the rest is charged to this module.
+	var begin time.Time
+	var ran time.Duration
+	m.app.construct.do(func() {
+		begin, ran = m.app.clock.Now(), m.app.runRuntime
+	})
+	if err = runInvoke(m.scope, i); err != nil {
+		err = m.suggestMissing(err, invokeInputs(i))
+	}
+	m.app.construct.do(func() {
+		if d := m.app.clock.Since(begin) - (m.app.runRuntime - ran); d > 0 {
+			m.runtime += d
+		}
+		m.app.attributeHooks(m)
+		m.log.LogEvent(&fxevent.Invoked{
+			FunctionName: fnName,
+			ModuleName:   m.name,
+			Err:          err,
+			Trace:        fmt.Sprintf("%+v", i.Stack), // format stack trace as multi-line
+		})
 	})
 	return err
 }
 
 func (m *module) decorateAll() error {
-	for _, d := range m.decorators {
-		if err := m.decorate(d); err != nil {
+	m.decoratorRan = make([]bool, len(m.decorators))
+	for i, d := range m.decorators {
+		if err := m.decorate(d, &m.decoratorRan[i]); err != nil {
 			return err
 		}
 	}
@@ -343,7 +473,9 @@ func (m *module) decorateAll() error {
 	return nil
 }
 
-func (m *module) decorate(d decorator) (err error) {
+// decorate adds d to the container.
+// ran is set once Dig has run the decorator.
+func (m *module) decorate(d decorator, ran *bool) (err error) {
 	if d.IsReplace {
 		return m.replace(d)
 	}
@@ -353,28 +485,31 @@ func (m *module) decorate(d decorator) (err error) {
 	opts := []dig.DecorateOption{
 		dig.FillDecorateInfo(&info),
 		dig.WithDecoratorCallback(func(ci dig.CallbackInfo) {
-			m.log.LogEvent(&fxevent.Run{
+			m.logRun(&fxevent.Run{
 				Name:       funcName,
 				Kind:       "decorate",
 				ModuleName: m.name,
 				Runtime:    ci.Runtime,
 				Err:        ci.Error,
-			})
+			}, ran)
 		}),
 	}
 
-	err = runDecorator(m.scope, d, opts...)
-	outputNames := make([]string, len(info.Outputs))
-	for i, o := range info.Outputs {
-		outputNames[i] = o.String()
+	if m.app.watchesConstructors() {
+		d = m.watchDecorator(d, funcName)
 	}
 
+	err = runDecorator(m.scope, d, opts...)
+	outputNames := typeNames(info.Outputs)
+	inputNames := typeNames(info.Inputs)
+
 	m.log.LogEvent(&fxevent.Decorated{
 		DecoratorName:   funcName,
 		StackTrace:      d.Stack.Strings(),
 		ModuleTrace:     append([]string{d.Stack[0].String()}, m.trace...),
 		ModuleName:      m.name,
 		OutputTypeNames: outputNames,
+		InputTypeNames:  inputNames,
 		Err:             err,
 	})
 
@@ -385,13 +520,13 @@ func (m *module) replace(d decorator) error {
 	typeName := d.ReplaceType.String()
 	opts := []dig.DecorateOption{
 		dig.WithDecoratorCallback(func(ci dig.CallbackInfo) {
-			m.log.LogEvent(&fxevent.Run{
+			m.logRun(&fxevent.Run{
 				Name:       fmt.Sprintf("stub(%v)", typeName),
 				Kind:       "replace",
 				ModuleName: m.name,
 				Runtime:    ci.Runtime,
 				Err:        ci.Error,
-			})
+			}, nil)
 		}),
 	}
 
@@ -261,7 +261,7 @@ func TestModuleSuccess(t *testing.T) {
 				desc:           "custom logger for module",
 				giveWithLogger: fx.NopLogger,
 				wantEvents: []string{
-					"Provided", "Provided", "Provided", "Supplied",
+					"Provided", "Provided", "Provided", "Provided", "Provided", "Supplied",
 					"Run", "LoggerInitialized", "Invoking", "Invoked",
 				},
 			},
@@ -269,8 +269,9 @@ func TestModuleSuccess(t *testing.T) {
 				desc:           "Not using a custom logger for module defaults to app logger",
 				giveWithLogger: fx.Options(),
 				wantEvents: []string{
-					"Provided", "Provided", "Provided", "Supplied", "Provided", "Run",
-					"LoggerInitialized", "Invoking", "Run", "Invoked", "Invoking", "Invoked",
+					"Provided", "Provided", "Provided", "Provided", "Provided", "Supplied", "Provided", "Run",
+					"LoggerInitialized", "ModuleBuilding", "Invoking", "Run", "Invoked", "ModuleBuilt",
+					"Invoking", "Invoked",
 				},
 			},
 		}
@@ -310,7 +311,7 @@ func TestModuleSuccess(t *testing.T) {
 
 				require.NoError(t, app.Err())
 
-				assert.Equal(t, []string{"Started", "Stopped"}, spy.EventTypes())
+				assert.Equal(t, []string{"Started", "StartupSummary", "Stopped"}, spy.EventTypes())
 			})
 		}
 	})
@@ -354,11 +355,11 @@ func TestModuleSuccess(t *testing.T) {
 
 		assert.Equal(t, []string{
 			"Provided", "Supplied", "Replaced", "Run", "Run",
-			"LoggerInitialized", "Invoking", "Run", "Invoked",
+			"LoggerInitialized", "ModuleBuilding", "Invoking", "Run", "Invoked", "ModuleBuilt",
 		}, moduleSpy.EventTypes())
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized", "Invoking", "Invoked",
 		}, appSpy.EventTypes())
 
@@ -369,7 +370,7 @@ func TestModuleSuccess(t *testing.T) {
 
 		require.NoError(t, app.Err())
 
-		assert.Equal(t, []string{"Started", "Stopped"}, appSpy.EventTypes())
+		assert.Equal(t, []string{"Started", "StartupSummary", "Stopped"}, appSpy.EventTypes())
 		assert.Empty(t, moduleSpy.EventTypes())
 	})
 
@@ -414,12 +415,15 @@ func TestModuleSuccess(t *testing.T) {
 
 		assert.Equal(t, []string{
 			"Supplied", "Provided", "Replaced", "Run", "Run", "LoggerInitialized",
+			// Grandchild is built while building the child.
+			"ModuleBuilding", "ModuleBuilding",
 			// Invoke logged twice, once from child and another from grandchild
-			"Invoking", "Run", "Invoked", "Invoking", "Invoked",
+			"Invoking", "Run", "Invoked", "ModuleBuilt", "Invoking", "Invoked",
+			"ModuleBuilt",
 		}, childSpy.EventTypes(), "events from grandchild also logged in child logger")
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized", "Invoking", "Invoked",
 		}, appSpy.EventTypes(), "events from modules do not appear in app logger")
 
@@ -430,7 +434,7 @@ func TestModuleSuccess(t *testing.T) {
 
 		require.NoError(t, app.Err())
 
-		assert.Equal(t, []string{"Started", "Stopped"}, appSpy.EventTypes())
+		assert.Equal(t, []string{"Started", "StartupSummary", "Stopped"}, appSpy.EventTypes())
 		assert.Empty(t, childSpy.EventTypes())
 	})
 }
@@ -660,7 +664,7 @@ func TestModuleFailures(t *testing.T) {
 				giveAppOpts:     spyAsLogger,
 				wantErrContains: []string{"error building logger"},
 				wantEvents: []string{
-					"Provided", "Provided", "Provided", "Supplied", "Run",
+					"Provided", "Provided", "Provided", "Provided", "Provided", "Supplied", "Run",
 					"LoggerInitialized", "Provided", "LoggerInitialized",
 				},
 			},
@@ -678,7 +682,7 @@ func TestModuleFailures(t *testing.T) {
 				giveAppOpts:     spyAsLogger,
 				wantErrContains: []string{"error building logger dependency"},
 				wantEvents: []string{
-					"Provided", "Provided", "Provided", "Supplied", "Run",
+					"Provided", "Provided", "Provided", "Provided", "Provided", "Supplied", "Run",
 					"LoggerInitialized", "Provided", "Provided", "Run", "LoggerInitialized",
 				},
 			},
@@ -690,7 +694,7 @@ func TestModuleFailures(t *testing.T) {
 					"fx.WithLogger", "from:", "Failed",
 				},
 				wantEvents: []string{
-					"Provided", "Provided", "Provided", "Supplied", "Run",
+					"Provided", "Provided", "Provided", "Provided", "Provided", "Supplied", "Run",
 					"LoggerInitialized", "Provided", "LoggerInitialized",
 				},
 			},
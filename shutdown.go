@@ -21,7 +21,10 @@
 package fx
 
 import (
+	"fmt"
 	"time"
+
+	"go.uber.org/fx/internal/fxreflect"
 )
 
 // Shutdowner provides a method that can manually trigger the shutdown of the
@@ -33,7 +36,7 @@ type Shutdowner interface {
 }
 
 // ShutdownOption provides a way to configure properties of the shutdown
-// process. Currently, no options have been implemented.
+// process, such as [ExitCode] and [ShutdownReason].
 type ShutdownOption interface {
 	apply(*shutdowner)
 }
@@ -54,6 +57,48 @@ func ExitCode(code int) ShutdownOption {
 	return exitCodeOption(code)
 }
 
+type shutdownReasonOption struct{ err error }
+
+func (o shutdownReasonOption) apply(s *shutdowner) {
+	s.reason = o.err
+}
+
+var _ ShutdownOption = shutdownReasonOption{}
+
+// ShutdownReason is a [ShutdownOption] that records why the application is
+// being shut down. The error is reported in the [fxevent.Stopping] event
+// and in the Reason field of the [ShutdownSignal] sent to Wait.
+//
+//	shutdowner.Shutdown(fx.ShutdownReason(err), fx.ExitCode(1))
+func ShutdownReason(err error) ShutdownOption {
+	return shutdownReasonOption{err: err}
+}
+
+type shutdownErrorOption struct{ err error }
+
+func (o shutdownErrorOption) apply(s *shutdowner) {
+	s.reason = o.err
+	s.failed = o.err != nil
+}
+
+var _ ShutdownOption = shutdownErrorOption{}
+
+// ShutdownError is a [ShutdownOption] that shuts down the application
+// because of the given error, such as a fatal error in a background task.
+//
+// Like [ShutdownReason], the error is reported in the [fxevent.Stopping]
+// event and in the Reason field of the [ShutdownSignal].
+// Additionally, [App.Err] reports the error once the application has
+// stopped, and the application exits with a code of 1
+// unless an [ExitCode] is given.
+//
+//	if err := worker.Run(); err != nil {
+//		shutdowner.Shutdown(fx.ShutdownError(err))
+//	}
+func ShutdownError(err error) ShutdownOption {
+	return shutdownErrorOption{err: err}
+}
+
 type shutdownTimeoutOption time.Duration
 
 func (shutdownTimeoutOption) apply(*shutdowner) {}
@@ -73,22 +118,75 @@ func ShutdownTimeout(timeout time.Duration) ShutdownOption {
 type shutdowner struct {
 	app      *App
 	exitCode int
+
+	// Set for a single call to Shutdown.
+	reason error
+	failed bool // whether ShutdownError was used
 }
 
 // Shutdown broadcasts a signal to all of the application's Done channels
 // and begins the Stop process. Applications can be shut down only after they
 // have finished starting up.
 func (s *shutdowner) Shutdown(opts ...ShutdownOption) error {
+	// The exit code is kept across calls, but not the other options.
+	call := shutdowner{app: s.app, exitCode: s.exitCode}
+	var hasExitCode bool
 	for _, opt := range opts {
-		opt.apply(s)
+		opt.apply(&call)
+		if _, ok := opt.(exitCodeOption); ok {
+			hasExitCode = true
+		}
+	}
+	s.exitCode = call.exitCode
+
+	exitCode := call.exitCode
+	if call.failed && !hasExitCode && exitCode == 0 {
+		exitCode = 1
+	}
+
+	var caller string
+	// Depth of 2 because CallerStack drops the last frame it reads.
+	if frames := fxreflect.CallerStack(1, 2); len(frames) > 0 {
+		caller = frames[0].String()
 	}
 
-	return s.app.receivers.b.Broadcast(ShutdownSignal{
+	sig := ShutdownSignal{
 		Signal:   _sigTERM,
-		ExitCode: s.exitCode,
+		ExitCode: exitCode,
+		Reason:   call.reason,
+	}
+	if record := s.app.recordShutdown; record != nil {
+		record(sig)
+		return nil
+	}
+
+	if call.failed {
+		s.app.setShutdownErr(call.reason)
+	}
+	return s.app.receivers.b.Broadcast(sig, shutdownSource{
+		origin: "shutdowner",
+		caller: caller,
+		failed: call.failed,
 	})
 }
 
 func (app *App) shutdowner() Shutdowner {
 	return &shutdowner{app: app}
 }
+
+// recordShutdownsOption makes the application's Shutdowner
+// pass signals to a function instead of broadcasting them.
+type recordShutdownsOption func(ShutdownSignal)
+
+func (o recordShutdownsOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fxtest.ShutdownRecorder Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.recordShutdown = o
+	}
+}
+
+func (o recordShutdownsOption) String() string {
+	return fmt.Sprintf("fxtest.ShutdownRecorder(%v)", fxreflect.FuncName(o))
+}
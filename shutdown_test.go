@@ -22,6 +22,7 @@ package fx_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -107,6 +108,90 @@ func TestShutdown(t *testing.T) {
 		require.Equal(t, 2, wait.ExitCode)
 	})
 
+	t.Run("signal compares equal", func(t *testing.T) {
+		t.Parallel()
+		var s fx.Shutdowner
+		app := fxtest.New(
+			t,
+			fx.Populate(&s),
+		)
+
+		require.NoError(t, app.Start(context.Background()), "error starting app")
+		assert.NoError(t, s.Shutdown(fx.ExitCode(2)), "error in app shutdown")
+		wait := <-app.Wait()
+		defer app.Stop(context.Background())
+		assert.True(t, wait == fx.ShutdownSignal{Signal: wait.Signal, ExitCode: 2},
+			"unexpected signal %#v", wait)
+	})
+
+	t.Run("with reason", func(t *testing.T) {
+		t.Parallel()
+		var s fx.Shutdowner
+		app := fxtest.New(
+			t,
+			fx.Populate(&s),
+		)
+
+		reason := errors.New("great sadness")
+		require.NoError(t, app.Start(context.Background()), "error starting app")
+		assert.NoError(t, s.Shutdown(fx.ShutdownReason(reason)), "error in app shutdown")
+		wait := <-app.Wait()
+		defer app.Stop(context.Background())
+		assert.Same(t, reason, wait.Reason)
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		t.Parallel()
+		var s fx.Shutdowner
+		app := fxtest.New(
+			t,
+			fx.Populate(&s),
+		)
+
+		giveErr := errors.New("great sadness")
+		require.NoError(t, app.Start(context.Background()), "error starting app")
+		assert.NoError(t, s.Shutdown(fx.ShutdownError(giveErr)), "error in app shutdown")
+		wait := <-app.Wait()
+		assert.NoError(t, app.Err(), "error should be reported only once the app has stopped")
+		require.NoError(t, app.Stop(context.Background()))
+		assert.Equal(t, 1, wait.ExitCode)
+		assert.Same(t, giveErr, wait.Reason)
+		assert.Same(t, giveErr, app.Err())
+	})
+
+	t.Run("with error and exit code", func(t *testing.T) {
+		t.Parallel()
+		var s fx.Shutdowner
+		app := fxtest.New(
+			t,
+			fx.Populate(&s),
+		)
+
+		giveErr := errors.New("great sadness")
+		require.NoError(t, app.Start(context.Background()), "error starting app")
+		assert.NoError(t, s.Shutdown(fx.ShutdownError(giveErr), fx.ExitCode(3)), "error in app shutdown")
+		wait := <-app.Wait()
+		defer app.Stop(context.Background())
+		assert.Equal(t, 3, wait.ExitCode)
+	})
+
+	t.Run("RunContext returns error", func(t *testing.T) {
+		t.Parallel()
+		giveErr := errors.New("great sadness")
+		app := fx.New(
+			fx.NopLogger,
+			fx.Invoke(func(s fx.Shutdowner) error {
+				return s.Shutdown(fx.ShutdownError(giveErr))
+			}),
+		)
+		err := app.RunContext(context.Background())
+		var exitErr *fx.ExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.Code)
+		assert.Same(t, giveErr, exitErr.Err)
+		assert.Same(t, giveErr, app.Err())
+	})
+
 	t.Run("with exit code and multiple Wait", func(t *testing.T) {
 		t.Parallel()
 		var s fx.Shutdowner
@@ -26,6 +26,8 @@ import (
 	"os"
 	"os/signal"
 	"sync"
+
+	"go.uber.org/fx/fxevent"
 )
 
 // ShutdownSignal represents a signal to be written to Wait or Done.
@@ -34,9 +36,17 @@ import (
 //
 // Should the application receive an operating system signal,
 // the Signal field will be populated with the received os.Signal.
+//
+// ShutdownSignals may be compared with == as long as the dynamic type
+// of Reason is comparable: comparing two signals whose Reasons have the
+// same non-comparable type panics, as it does for any interface value.
 type ShutdownSignal struct {
 	Signal   os.Signal
 	ExitCode int
+
+	// Reason is the error passed to [ShutdownReason] or [ShutdownError],
+	// if any.
+	Reason error
 }
 
 // String will render a ShutdownSignal type as a string suitable for printing.
@@ -44,6 +54,38 @@ func (sig ShutdownSignal) String() string {
 	return fmt.Sprintf("%v", sig.Signal)
 }
 
+// shutdownSource describes where a ShutdownSignal came from, and why.
+// It's only reported in the Stopping event, so it's kept out of
+// ShutdownSignal and sent along with it in a sourcedSignal.
+type shutdownSource struct {
+	// "shutdowner", or "context" if the context passed to RunContext
+	// is done. Empty for signals sent to the process.
+	origin string
+
+	// Caller of Shutdowner.Shutdown.
+	caller string
+
+	// Whether ShutdownError was used.
+	failed bool
+}
+
+// stoppingEvent builds the event logged when the application stops
+// because of sig, which came from src.
+func (sig ShutdownSignal) stoppingEvent(src shutdownSource) *fxevent.Stopping {
+	origin := src.origin
+	if origin == "" {
+		origin = "signal"
+	}
+	return &fxevent.Stopping{
+		Signal:   sig.Signal,
+		Origin:   origin,
+		Caller:   src.caller,
+		ExitCode: sig.ExitCode,
+		Reason:   sig.Reason,
+		Failed:   src.failed,
+	}
+}
+
 func newSignalReceivers() signalReceivers {
 	return signalReceivers{
 		notify:     signal.Notify,
@@ -87,7 +129,7 @@ func (recv *signalReceivers) relayer() {
 	case signal := <-recv.signals:
 		recv.b.Broadcast(ShutdownSignal{
 			Signal: signal,
-		})
+		}, shutdownSource{})
 	}
 }
 
@@ -144,3 +186,7 @@ func (recv *signalReceivers) Done() <-chan os.Signal {
 func (recv *signalReceivers) Wait() <-chan ShutdownSignal {
 	return recv.b.Wait()
 }
+
+func (recv *signalReceivers) WaitSourced() <-chan sourcedSignal {
+	return recv.b.WaitSourced()
+}
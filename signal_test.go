@@ -57,9 +57,9 @@ func TestSignal(t *testing.T) {
 			Signal: syscall.SIGTERM,
 		}
 
-		require.NoError(t, recv.b.Broadcast(expected), "first broadcast should succeed")
+		require.NoError(t, recv.b.Broadcast(expected, shutdownSource{}), "first broadcast should succeed")
 
-		assertUnsentSignalError(t, recv.b.Broadcast(expected), &unsentSignalError{
+		assertUnsentSignalError(t, recv.b.Broadcast(expected, shutdownSource{}), &unsentSignalError{
 			Signal: expected,
 			Total:  2,
 			Unsent: 2,
@@ -69,6 +69,28 @@ func TestSignal(t *testing.T) {
 		assert.Equal(t, expected.Signal, <-recv.Done(), "expect cached signal")
 	})
 
+	t.Run("WaitSourced", func(t *testing.T) {
+		t.Parallel()
+		recv := newSignalReceivers()
+		ch := recv.WaitSourced()
+
+		first := sourcedSignal{
+			signal: ShutdownSignal{Signal: syscall.SIGTERM, ExitCode: 3},
+			source: shutdownSource{origin: "shutdowner", caller: "first"},
+		}
+		second := sourcedSignal{
+			signal: ShutdownSignal{Signal: syscall.SIGTERM, ExitCode: 1},
+			source: shutdownSource{origin: "shutdowner", caller: "second", failed: true},
+		}
+		require.NoError(t, recv.b.Broadcast(first.signal, first.source))
+		require.Error(t, recv.b.Broadcast(second.signal, second.source), "channel is full")
+
+		// The source arrives with the signal it belongs to.
+		assert.Equal(t, first, <-ch)
+		assert.Equal(t, second, <-recv.WaitSourced(), "expect cached signal")
+		assert.Equal(t, second.signal, <-recv.Wait(), "expect cached signal")
+	})
+
 	t.Run("signal notify relayer", func(t *testing.T) {
 		t.Parallel()
 		t.Run("start and stop", func(t *testing.T) {
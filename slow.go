@@ -0,0 +1,159 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"go.uber.org/dig"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxclock"
+)
+
+// SlowThreshold reports lifecycle hooks and constructors that take longer
+// than expected to run with [fxevent.SlowHook] and
+// [fxevent.SlowConstructor] events.
+//
+// start applies to each OnStart and OnStop hook,
+// and construct applies to each constructor and decorator.
+// A threshold of zero disables the corresponding report.
+//
+// The events are logged as soon as a function exceeds its threshold,
+// while it's still running. This helps find a function that hangs
+// before the [StartTimeout] or [StopTimeout] is exceeded.
+//
+//	fx.New(
+//		fx.SlowThreshold(time.Second, 100*time.Millisecond),
+//		...
+//	)
+func SlowThreshold(start, construct time.Duration) Option {
+	return slowThresholdOption{start: start, construct: construct}
+}
+
+type slowThresholdOption struct {
+	start     time.Duration
+	construct time.Duration
+}
+
+func (o slowThresholdOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.SlowThreshold Option should be passed to top-level App, " +
+			"not to fx.Module")
+	} else {
+		m.app.slowHookThreshold = o.start
+		m.app.slowConstructThreshold = o.construct
+	}
+}
+
+func (o slowThresholdOption) String() string {
+	return fmt.Sprintf("fx.SlowThreshold(%v, %v)", o.start, o.construct)
+}
+
+// watchesConstructors reports whether constructors and decorators need
+// to be wrapped to report them if they're slow, or to track whether
+// they're running for NewContext.
+func (app *App) watchesConstructors() bool {
+	return app.slowConstructThreshold > 0 || app.constructCtx != nil
+}
+
+// watchProvide wraps the constructor of p to report it if it's slow.
+// Options are added to keep Dig's errors pointing at the original constructor.
+func (m *module) watchProvide(p provide, name string, opts []dig.ProvideOption) (provide, []dig.ProvideOption) {
+	switch target := p.Target.(type) {
+	case annotated:
+		// fx.Annotate already keeps track of the original function.
+		target.Target = m.watchFunc(target.Target, name, "provide")
+		p.Target = target
+	case Annotated:
+		if fv := reflect.ValueOf(target.Target); fv.Kind() == reflect.Func {
+			opts = append(opts, dig.LocationForPC(fv.Pointer()))
+			target.Target = m.watchFunc(target.Target, name, "provide")
+			p.Target = target
+		}
+	default:
+		if fv := reflect.ValueOf(target); fv.Kind() == reflect.Func {
+			opts = append(opts, dig.LocationForPC(fv.Pointer()))
+			p.Target = m.watchFunc(target, name, "provide")
+		}
+	}
+	return p, opts
+}
+
+// watchDecorator wraps the decorator d to report it if it's slow.
+func (m *module) watchDecorator(d decorator, name string) decorator {
+	switch target := d.Target.(type) {
+	case annotated:
+		target.Target = m.watchFunc(target.Target, name, "decorate")
+		d.Target = target
+	default:
+		d.Target = m.watchFunc(target, name, "decorate")
+	}
+	return d
+}
+
+// watchFunc returns a function with the same signature as fn that logs
+// a SlowConstructor event if fn runs past the construct threshold,
+// and records that fn is running if the App has a construction context.
+//
+// fn is returned as-is if it's not a function,
+// leaving it to Dig to report the error.
+func (m *module) watchFunc(fn interface{}, name, kind string) interface{} {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func {
+		return fn
+	}
+
+	call := fv.Call
+	if fv.Type().IsVariadic() {
+		call = fv.CallSlice
+	}
+
+	threshold := m.app.slowConstructThreshold
+	track := m.app.constructCtx != nil
+	return reflect.MakeFunc(fv.Type(), func(args []reflect.Value) []reflect.Value {
+		if track {
+			defer m.app.running.push(runningFunc{
+				kind:       kind,
+				name:       name,
+				moduleName: m.name,
+			})()
+		}
+		if threshold <= 0 {
+			return call(args)
+		}
+
+		log := m.log
+		defer fxclock.Watch(m.app.clock, threshold, func(runtime time.Duration) {
+			m.app.construct.do(func() {
+				log.LogEvent(&fxevent.SlowConstructor{
+					Name:       name,
+					Kind:       kind,
+					ModuleName: m.name,
+					Threshold:  threshold,
+					Runtime:    runtime,
+				})
+			})
+		})()
+		return call(args)
+	}).Interface()
+}
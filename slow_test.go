@@ -0,0 +1,188 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxclock"
+	"go.uber.org/fx/internal/fxlog"
+)
+
+func TestSlowThreshold(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+
+	// awaitEvent blocks until the spy has seen an event of the given type,
+	// returning it.
+	awaitEvent := func(t *testing.T, spy *fxlog.Spy, typeName string) fxevent.Event {
+		var e fxevent.Event
+		require.Eventually(t, func() bool {
+			events := spy.Events().SelectByTypeName(typeName)
+			if len(events) == 0 {
+				return false
+			}
+			e = events[0]
+			return true
+		}, time.Second, time.Millisecond)
+		return e
+	}
+
+	t.Run("slow constructor", func(t *testing.T) {
+		t.Parallel()
+
+		clock := fxclock.NewMock()
+		spy := new(fxlog.Spy)
+		app := NewForTest(t,
+			WithLogger(func() fxevent.Logger { return spy }),
+			WithClock(clock),
+			SlowThreshold(0, time.Second),
+			Module("child",
+				Provide(func() *A {
+					clock.Add(2 * time.Second)
+					// Must be reported while still running.
+					e := awaitEvent(t, spy, "SlowConstructor").(*fxevent.SlowConstructor)
+					assert.Contains(t, e.Name, "TestSlowThreshold")
+					assert.Equal(t, "provide", e.Kind)
+					assert.Equal(t, "child", e.ModuleName)
+					assert.Equal(t, time.Second, e.Threshold)
+					assert.GreaterOrEqual(t, e.Runtime, time.Second)
+					return &A{}
+				}),
+			),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, app.Err())
+		assert.Len(t, spy.Events().SelectByTypeName("SlowConstructor"), 1)
+	})
+
+	t.Run("slow decorator", func(t *testing.T) {
+		t.Parallel()
+
+		clock := fxclock.NewMock()
+		spy := new(fxlog.Spy)
+		app := NewForTest(t,
+			WithLogger(func() fxevent.Logger { return spy }),
+			WithClock(clock),
+			SlowThreshold(0, time.Second),
+			Provide(func() *A { return &A{} }),
+			Decorate(func(a *A) *A {
+				clock.Add(2 * time.Second)
+				return a
+			}),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, app.Err())
+
+		e := awaitEvent(t, spy, "SlowConstructor").(*fxevent.SlowConstructor)
+		assert.Equal(t, "decorate", e.Kind)
+	})
+
+	t.Run("slow hook", func(t *testing.T) {
+		t.Parallel()
+
+		clock := fxclock.NewMock()
+		spy := new(fxlog.Spy)
+		app := NewForTest(t,
+			WithLogger(func() fxevent.Logger { return spy }),
+			WithClock(clock),
+			SlowThreshold(time.Second, 0),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStart: func(context.Context) error {
+						clock.Add(2 * time.Second)
+						// Must be reported while still running.
+						e := awaitEvent(t, spy, "SlowHook").(*fxevent.SlowHook)
+						assert.Equal(t, "OnStart", e.Method)
+						assert.Contains(t, e.CallerName, "TestSlowThreshold")
+						assert.Equal(t, time.Second, e.Threshold)
+						assert.GreaterOrEqual(t, e.Runtime, time.Second)
+						return nil
+					},
+				})
+			}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+		require.NoError(t, app.Stop(context.Background()))
+		assert.Len(t, spy.Events().SelectByTypeName("SlowHook"), 1)
+	})
+
+	t.Run("fast", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		app := NewForTest(t,
+			WithLogger(func() fxevent.Logger { return spy }),
+			SlowThreshold(time.Minute, time.Minute),
+			Provide(
+				func() *A { return &A{} },
+				Annotate(func(...string) *B { return &B{} }, ResultTags(`name:"b"`)),
+				Annotated{Name: "a", Target: func() *A { return &A{} }},
+			),
+			Invoke(func(lc Lifecycle, _ *A, _ struct {
+				In
+
+				A *A `name:"a"`
+				B *B `name:"b"`
+			},
+			) {
+				lc.Append(Hook{OnStart: func(context.Context) error { return nil }})
+			}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+		require.NoError(t, app.Stop(context.Background()))
+
+		assert.Empty(t, spy.Events().SelectByTypeName("SlowConstructor"))
+		assert.Empty(t, spy.Events().SelectByTypeName("SlowHook"))
+	})
+
+	t.Run("errors point to the original constructor", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t,
+			SlowThreshold(time.Minute, time.Minute),
+			Provide(func(*B) *A { return &A{} }),
+			Invoke(func(*A) {}),
+		)
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "TestSlowThreshold")
+		assert.NotContains(t, err.Error(), "makeFuncStub")
+	})
+
+	t.Run("not allowed in modules", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t,
+			Module("child", SlowThreshold(time.Second, time.Second)),
+		)
+		require.Error(t, app.Err())
+		assert.Contains(t, app.Err().Error(), "fx.SlowThreshold Option should be passed to top-level App")
+	})
+}
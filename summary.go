@@ -0,0 +1,147 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"sort"
+
+	"go.uber.org/fx/fxevent"
+)
+
+// Number of entries in each of the slowest lists of the startup summary.
+const _startupSummarySlowest = 5
+
+// logRun logs that a constructor, decorator, or stub from this module
+// was run by Dig, and records it for the startup summary.
+// ran is set to true if it's non-nil.
+func (m *module) logRun(e *fxevent.Run, ran *bool) {
+	m.app.construct.do(func() {
+		if ran != nil {
+			*ran = true
+		}
+		m.app.runs = append(m.app.runs, e)
+		m.app.runRuntime += e.Runtime
+		m.runtime += e.Runtime
+		m.app.attributeHooks(m)
+		m.log.LogEvent(e)
+	})
+}
+
+// attributeHooks attributes all lifecycle hooks appended since the last call
+// to the given module.
+//
+// This must be called after each function from the module is run.
+// Dig runs the dependencies of a function before the function itself,
+// so hooks appended since the previous function finished
+// were appended by this function.
+func (app *App) attributeHooks(m *module) {
+	for n := app.lifecycle.NumHooks(); len(app.hookModules) < n; {
+		app.hookModules = append(app.hookModules, m)
+	}
+}
+
+// hookModule reports the module that appended the hook at the given index
+// to the lifecycle. The module is nil for hooks of the root module.
+func (app *App) hookModule(index int) (interface{}, string) {
+	if index < len(app.hookModules) {
+		if m := app.hookModules[index]; m.parent != nil {
+			return m, m.name
+		}
+	}
+	return nil, ""
+}
+
+// hookModuleName returns the name of the module that appended the hook at
+// the given index, or an empty string for hooks of the root module.
+func (app *App) hookModuleName(index int) string {
+	if index >= len(app.hookModules) {
+		return ""
+	}
+	if m := app.hookModules[index]; m.parent != nil {
+		return m.name
+	}
+	return ""
+}
+
+// startupSummary summarizes the work done to build and start the application.
+func (app *App) startupSummary() *fxevent.StartupSummary {
+	// Hooks appended outside of constructors and invokes,
+	// e.g. from other hooks, are attributed to the root module.
+	app.attributeHooks(app.root)
+
+	hookCounts := make(map[*module]int)
+	for _, m := range app.hookModules {
+		hookCounts[m]++
+	}
+
+	var summary fxevent.StartupSummary
+	var summarize func(*module)
+	summarize = func(m *module) {
+		summary.Modules = append(summary.Modules, fxevent.ModuleSummary{
+			ModuleName: m.name,
+			Provides:   len(m.provides),
+			Decorators: len(m.decorators),
+			Invokes:    len(m.invokes),
+			Hooks:      hookCounts[m],
+		})
+		for _, child := range m.modules {
+			summarize(child)
+		}
+	}
+	summarize(app.root)
+
+	constructors := make([]fxevent.FuncRuntime, len(app.runs))
+	for i, r := range app.runs {
+		summary.ConstructorRuntime += r.Runtime
+		constructors[i] = fxevent.FuncRuntime{
+			Name:       r.Name,
+			ModuleName: r.ModuleName,
+			Runtime:    r.Runtime,
+		}
+	}
+	summary.SlowestConstructors = slowestFuncs(constructors)
+
+	records := app.lifecycle.StartRecords()
+	hooks := make([]fxevent.FuncRuntime, len(records))
+	for i, r := range records {
+		summary.OnStartRuntime += r.Runtime
+		hooks[i] = fxevent.FuncRuntime{
+			Name:       r.Name,
+			ModuleName: app.hookModules[r.Index].name,
+			Runtime:    r.Runtime,
+		}
+	}
+	summary.SlowestHooks = slowestFuncs(hooks)
+
+	return &summary
+}
+
+// slowestFuncs returns the slowest of the given functions,
+// starting with the slowest. It modifies the given slice.
+func slowestFuncs(funcs []fxevent.FuncRuntime) []fxevent.FuncRuntime {
+	sort.SliceStable(funcs, func(i, j int) bool {
+		return funcs[i].Runtime > funcs[j].Runtime
+	})
+	if len(funcs) > _startupSummarySlowest {
+		funcs = funcs[:_startupSummarySlowest]
+	}
+	return funcs
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2024 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxclock"
+	"go.uber.org/fx/internal/fxlog"
+)
+
+func TestStartupSummary(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+
+	noop := func(context.Context) error { return nil }
+
+	t.Run("modules and hooks", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(
+			Module("child",
+				Provide(func(lc Lifecycle) *A {
+					lc.Append(Hook{OnStart: noop, OnStop: noop})
+					return &A{}
+				}),
+				Supply(&B{}),
+				Decorate(func(b *B) *B { return b }),
+			),
+			Invoke(func(lc Lifecycle, _ *A, _ *B) {
+				lc.Append(Hook{OnStart: noop})
+				lc.Append(Hook{OnStop: noop})
+			}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+		defer app.Stop(context.Background())
+
+		events := spy.Events().SelectByTypeName("StartupSummary")
+		require.Len(t, events, 1)
+		summary := events[0].(*fxevent.StartupSummary)
+
+		assert.Equal(t, []fxevent.ModuleSummary{
+			{Invokes: 1, Hooks: 2},
+			{ModuleName: "child", Provides: 2, Decorators: 1, Hooks: 1},
+		}, summary.Modules)
+
+		var runtime time.Duration
+		for _, e := range spy.Events().SelectByTypeName("Run") {
+			runtime += e.(*fxevent.Run).Runtime
+		}
+		assert.Equal(t, runtime, summary.ConstructorRuntime)
+
+		require.Len(t, summary.SlowestHooks, 2)
+		var hookModules []string
+		for _, h := range summary.SlowestHooks {
+			hookModules = append(hookModules, h.ModuleName)
+		}
+		assert.ElementsMatch(t, []string{"child", ""}, hookModules)
+	})
+
+	t.Run("slowest constructors", func(t *testing.T) {
+		t.Parallel()
+
+		type (
+			T1 struct{}
+			T2 struct{}
+			T3 struct{}
+			T4 struct{}
+			T5 struct{}
+			T6 struct{}
+		)
+		app, spy := NewSpied(
+			Supply(T1{}, T2{}, T3{}, T4{}, T5{}, T6{}),
+			Invoke(func(T1, T2, T3, T4, T5, T6) {}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+		defer app.Stop(context.Background())
+
+		summary := spy.Events().SelectByTypeName("StartupSummary")[0].(*fxevent.StartupSummary)
+		require.Len(t, summary.SlowestConstructors, 5)
+		for i := 1; i < len(summary.SlowestConstructors); i++ {
+			assert.GreaterOrEqual(t,
+				summary.SlowestConstructors[i-1].Runtime,
+				summary.SlowestConstructors[i].Runtime,
+				"must be sorted slowest first")
+		}
+	})
+
+	t.Run("not emitted on failure", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{OnStart: func(context.Context) error {
+					return errors.New("great sadness")
+				}})
+			}),
+		)
+		require.Error(t, app.Start(context.Background()))
+		assert.Empty(t, spy.Events().SelectByTypeName("StartupSummary"))
+	})
+}
+
+func TestModuleEvents(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+
+	clock := fxclock.NewMock()
+	spy := new(fxlog.Spy)
+	app := NewForTest(t,
+		WithLogger(func() fxevent.Logger { return spy }),
+		WithClock(clock),
+		Module("platform",
+			Provide(func(lc Lifecycle) *A {
+				clock.Add(time.Second)
+				lc.Append(Hook{
+					OnStart: func(context.Context) error {
+						clock.Add(2 * time.Second)
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						clock.Add(3 * time.Second)
+						return nil
+					},
+				})
+				return &A{}
+			}),
+			Invoke(func(*A) {}),
+		),
+		Invoke(func(lc Lifecycle) {
+			lc.Append(Hook{OnStart: func(context.Context) error { return nil }})
+		}),
+	)
+	require.NoError(t, app.Start(context.Background()))
+	require.NoError(t, app.Stop(context.Background()))
+
+	var got []fxevent.Event
+	for _, e := range spy.Events() {
+		switch e := e.(type) {
+		case *fxevent.ModuleBuilt:
+			// Dig times constructors with the system clock, so the
+			// runtime of the invoke is only close to what the mock says.
+			assert.InDelta(t, time.Second, e.Runtime, float64(10*time.Millisecond))
+			built := *e
+			built.Runtime = time.Second
+			got = append(got, &built)
+		case *fxevent.ModuleBuilding,
+			*fxevent.ModuleHooksExecuting, *fxevent.ModuleHooksExecuted:
+			got = append(got, e)
+		}
+	}
+
+	// Hooks of the root module are not reported.
+	assert.Equal(t, []fxevent.Event{
+		&fxevent.ModuleBuilding{ModuleName: "platform"},
+		&fxevent.ModuleBuilt{ModuleName: "platform", Runtime: time.Second},
+		&fxevent.ModuleHooksExecuting{ModuleName: "platform", Method: "OnStart"},
+		&fxevent.ModuleHooksExecuted{ModuleName: "platform", Method: "OnStart", Runtime: 2 * time.Second},
+		&fxevent.ModuleHooksExecuting{ModuleName: "platform", Method: "OnStop"},
+		&fxevent.ModuleHooksExecuted{ModuleName: "platform", Method: "OnStop", Runtime: 3 * time.Second},
+	}, got)
+}
+
+func TestModuleBuiltRuntime(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+
+	const sleep = 50 * time.Millisecond
+	spy := new(fxlog.Spy)
+	app := NewForTest(t,
+		WithLogger(func() fxevent.Logger { return spy }),
+		// "consumer" is built first, so its invoke runs
+		// the constructor of "provider".
+		Module("consumer", Invoke(func(*A) {})),
+		Module("provider",
+			Provide(func() *A {
+				time.Sleep(sleep)
+				return &A{}
+			}),
+		),
+	)
+	require.NoError(t, app.Err())
+
+	runtimes := make(map[string]time.Duration)
+	for _, e := range spy.Events().SelectByTypeName("ModuleBuilt") {
+		e := e.(*fxevent.ModuleBuilt)
+		runtimes[e.ModuleName] = e.Runtime
+	}
+	assert.GreaterOrEqual(t, runtimes["provider"], sleep,
+		"constructor should be charged to the module it was provided to")
+	assert.Less(t, runtimes["consumer"], sleep,
+		"constructor should not be charged to the module that needed it")
+}